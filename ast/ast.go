@@ -0,0 +1,56 @@
+// Package ast holds a Go-native snapshot of the abstract syntax tree of a
+// translation unit.
+//
+// Contrary to clang.Cursor values, which are only usable while their
+// translation unit is alive, the values of this package are plain Go
+// structs: they can be cached, or serialized with encoding/json or
+// encoding/gob and shipped to other processes.
+//
+// Package ast does not depend on libclang: snapshots are created with
+// package github.com/sbinet/go-clang/ast/astclang, and can be used by
+// tools built without libclang.
+package ast
+
+// TranslationUnit is the snapshot of a translation unit.
+type TranslationUnit struct {
+	Spelling string `json:"spelling"` // name of the main file
+	Root     *Node  `json:"root"`     // translation unit cursor
+}
+
+// Node is the snapshot of a cursor.
+type Node struct {
+	Kind          string   `json:"kind"`                     // spelling of the cursor kind (e.g. "FunctionDecl")
+	Spelling      string   `json:"spelling,omitempty"`       // name of the entity
+	USR           string   `json:"usr,omitempty"`            // Unified Symbol Resolution of the entity
+	Type          string   `json:"type,omitempty"`           // spelling of the type of the cursor
+	CanonicalType string   `json:"canonical_type,omitempty"` // spelling of the canonical type of the cursor
+	Location      Location `json:"location"`
+	Extent        Range    `json:"extent"`
+	Children      []*Node  `json:"children,omitempty"`
+}
+
+// Location is a position in a source file.
+// Lines and columns are 1-based. The zero Location is the null location.
+type Location struct {
+	File   string `json:"file,omitempty"`
+	Line   uint   `json:"line,omitempty"`
+	Column uint   `json:"column,omitempty"`
+	Offset uint   `json:"offset,omitempty"` // byte offset in File
+}
+
+// Range is a half-open range of source code, [Start, End).
+type Range struct {
+	Start Location `json:"start"`
+	End   Location `json:"end"`
+}
+
+// Walk traverses the tree rooted at n in depth-first pre-order, calling fn
+// for each node. The children of a node are skipped if fn returns false.
+func Walk(n *Node, fn func(n *Node) bool) {
+	if n == nil || !fn(n) {
+		return
+	}
+	for _, child := range n.Children {
+		Walk(child, fn)
+	}
+}
@@ -0,0 +1,85 @@
+package ast_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/sbinet/go-clang/ast"
+)
+
+func newTU() *ast.TranslationUnit {
+	loc := func(line, col, off uint) ast.Location {
+		return ast.Location{File: "foo.c", Line: line, Column: col, Offset: off}
+	}
+	return &ast.TranslationUnit{
+		Spelling: "foo.c",
+		Root: &ast.Node{
+			Kind:     "TranslationUnit",
+			Spelling: "foo.c",
+			Children: []*ast.Node{
+				{
+					Kind:          "VarDecl",
+					Spelling:      "x",
+					USR:           "c:@x",
+					Type:          "size_t",
+					CanonicalType: "unsigned long",
+					Location:      loc(1, 8, 7),
+					Extent:        ast.Range{Start: loc(1, 1, 0), End: loc(1, 9, 8)},
+				},
+			},
+		},
+	}
+}
+
+func TestJSON(t *testing.T) {
+	want := newTU()
+	buf, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("error encoding: %v", err)
+	}
+	var got ast.TranslationUnit
+	if err := json.Unmarshal(buf, &got); err != nil {
+		t.Fatalf("error decoding: %v", err)
+	}
+	if !reflect.DeepEqual(&got, want) {
+		t.Errorf("JSON round-trip mismatch:\ngot= %+v\nwant=%+v", &got, want)
+	}
+}
+
+func TestGob(t *testing.T) {
+	want := newTU()
+	buf := new(bytes.Buffer)
+	if err := gob.NewEncoder(buf).Encode(want); err != nil {
+		t.Fatalf("error encoding: %v", err)
+	}
+	var got ast.TranslationUnit
+	if err := gob.NewDecoder(buf).Decode(&got); err != nil {
+		t.Fatalf("error decoding: %v", err)
+	}
+	if !reflect.DeepEqual(&got, want) {
+		t.Errorf("gob round-trip mismatch:\ngot= %+v\nwant=%+v", &got, want)
+	}
+}
+
+func TestWalk(t *testing.T) {
+	var kinds []string
+	ast.Walk(newTU().Root, func(n *ast.Node) bool {
+		kinds = append(kinds, n.Kind)
+		return true
+	})
+	if len(kinds) != 2 || kinds[0] != "TranslationUnit" || kinds[1] != "VarDecl" {
+		t.Errorf("expected [TranslationUnit VarDecl]. got=%v", kinds)
+	}
+
+	n := 0
+	ast.Walk(newTU().Root, func(*ast.Node) bool {
+		n++
+		return false
+	})
+	if n != 1 {
+		t.Errorf("expected children to be skipped. got=%d nodes", n)
+	}
+}
@@ -0,0 +1,34 @@
+// Package astclang creates Go-native snapshots of the abstract syntax tree
+// of libclang translation units.
+//
+// ex:
+//
+//	tu := idx.Parse("foo.c", args, nil, 0)
+//	defer tu.Dispose()
+//	snap := astclang.New(tu, astclang.Options{MainFileOnly: true})
+//	err := json.NewEncoder(os.Stdout).Encode(snap)
+package astclang
+
+import (
+	"github.com/sbinet/go-clang"
+	"github.com/sbinet/go-clang/ast"
+)
+
+// Options controls the creation of a snapshot.
+type Options struct {
+	// MainFileOnly restricts the snapshot to the cursors located in the
+	// main file of the translation unit, skipping the declarations
+	// brought in by included headers.
+	MainFileOnly bool
+}
+
+// New returns the snapshot of the abstract syntax tree of tu.
+func New(tu clang.TranslationUnit, opts Options) *ast.TranslationUnit {
+	return tu.AST(opts.MainFileOnly)
+}
+
+// NewNode returns the snapshot of the tree of cursors rooted at c.
+// The MainFileOnly option applies to the descendants of c, not to c itself.
+func NewNode(c clang.Cursor, opts Options) *ast.Node {
+	return c.ASTNode(opts.MainFileOnly)
+}
@@ -0,0 +1,83 @@
+package astclang_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/sbinet/go-clang"
+	"github.com/sbinet/go-clang/ast"
+	"github.com/sbinet/go-clang/ast/astclang"
+)
+
+func TestNew(t *testing.T) {
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := idx.Parse("../../testdata/includes.c", nil, nil, 0)
+	if !tu.IsValid() {
+		t.Fatal("TranslationUnit is not valid")
+	}
+	defer tu.Dispose()
+
+	all := astclang.New(tu, astclang.Options{})
+	main := astclang.New(tu, astclang.Options{MainFileOnly: true})
+
+	if all.Root.Kind != "TranslationUnit" {
+		t.Errorf("expected a TranslationUnit root. got=%q", all.Root.Kind)
+	}
+	if len(main.Root.Children) == 0 || len(main.Root.Children) >= len(all.Root.Children) {
+		t.Errorf("expected main file filter to drop some declarations. got=%d (all=%d)",
+			len(main.Root.Children), len(all.Root.Children))
+	}
+	for _, n := range main.Root.Children {
+		if n.Location.File != tu.Spelling() {
+			t.Errorf("%s %q: expected to be located in %q. got=%q", n.Kind, n.Spelling, tu.Spelling(), n.Location.File)
+		}
+	}
+
+	var add *ast.Node
+	ast.Walk(all.Root, func(n *ast.Node) bool {
+		if n.Kind == "FunctionDecl" && n.Spelling == "add" {
+			add = n
+		}
+		return add == nil
+	})
+	if add == nil {
+		t.Fatal("could not find function 'add'")
+	}
+	if add.USR != "c:@F@add" {
+		t.Errorf("expected USR 'c:@F@add'. got=%q", add.USR)
+	}
+	if add.Type != "int (int, int)" || add.CanonicalType != "int (int, int)" {
+		t.Errorf("unexpected type %q (canonical: %q)", add.Type, add.CanonicalType)
+	}
+	if add.Location.Line == 0 || add.Extent.End.Line < add.Extent.Start.Line {
+		t.Errorf("invalid location %+v (extent: %+v)", add.Location, add.Extent)
+	}
+
+	buf, err := json.Marshal(all)
+	if err != nil {
+		t.Fatalf("error encoding JSON: %v", err)
+	}
+	var fromJSON ast.TranslationUnit
+	if err := json.Unmarshal(buf, &fromJSON); err != nil {
+		t.Fatalf("error decoding JSON: %v", err)
+	}
+	if !reflect.DeepEqual(&fromJSON, all) {
+		t.Errorf("JSON round-trip mismatch")
+	}
+
+	gbuf := new(bytes.Buffer)
+	if err := gob.NewEncoder(gbuf).Encode(all); err != nil {
+		t.Fatalf("error encoding gob: %v", err)
+	}
+	var fromGob ast.TranslationUnit
+	if err := gob.NewDecoder(gbuf).Decode(&fromGob); err != nil {
+		t.Fatalf("error decoding gob: %v", err)
+	}
+	if !reflect.DeepEqual(&fromGob, all) {
+		t.Errorf("gob round-trip mismatch")
+	}
+}
@@ -0,0 +1,57 @@
+package clang
+
+import (
+	"github.com/sbinet/go-clang/ast"
+)
+
+// ASTNode returns the Go-native snapshot of the tree of cursors rooted at c
+// (see package ast). The snapshot stays usable once the translation unit is
+// disposed.
+// If mainFileOnly is true, the descendants of c which are not located in
+// the main file of the translation unit are skipped.
+func (c Cursor) ASTNode(mainFileOnly bool) *ast.Node {
+	n := &ast.Node{
+		Kind:     c.Kind().Spelling(),
+		Spelling: c.Spelling(),
+		USR:      c.USR(),
+		Location: astLocation(c.Location()),
+		Extent:   astRange(c.Extent()),
+	}
+	if t := c.Type(); t.Kind() != TK_Invalid {
+		n.Type = t.TypeSpelling()
+		n.CanonicalType = t.CanonicalType().TypeSpelling()
+	}
+
+	for _, child := range c.Children() {
+		if mainFileOnly && !child.Location().IsFromMainFile() {
+			continue
+		}
+		n.Children = append(n.Children, child.ASTNode(mainFileOnly))
+	}
+	return n
+}
+
+// AST returns the Go-native snapshot of the abstract syntax tree of tu.
+func (tu TranslationUnit) AST(mainFileOnly bool) *ast.TranslationUnit {
+	return &ast.TranslationUnit{
+		Spelling: tu.Spelling(),
+		Root:     tu.ToCursor().ASTNode(mainFileOnly),
+	}
+}
+
+func astLocation(loc SourceLocation) ast.Location {
+	f, line, col, off := loc.ExpansionLocation()
+	return ast.Location{
+		File:   f.Name(),
+		Line:   line,
+		Column: col,
+		Offset: off,
+	}
+}
+
+func astRange(r SourceRange) ast.Range {
+	return ast.Range{
+		Start: astLocation(r.Start()),
+		End:   astLocation(r.End()),
+	}
+}
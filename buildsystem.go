@@ -0,0 +1,149 @@
+package clang
+
+// #include <stdlib.h>
+// #include "go-clang.h"
+// #include "clang-c/BuildSystem.h"
+//
+import "C"
+
+import (
+	"unsafe"
+)
+
+/**
+ * \brief Return the timestamp for use with Clang's
+ * \c -fbuild-session-timestamp= option.
+ */
+func BuildSessionTimestamp() uint64 {
+	return uint64(C.clang_getBuildSessionTimestamp())
+}
+
+/**
+ * \brief Object encapsulating information about overlaying virtual
+ * file/directories over the real file system.
+ */
+type VirtualFileOverlay struct {
+	c C.CXVirtualFileOverlay
+}
+
+/**
+ * \brief Create a \c CXVirtualFileOverlay object.
+ * Must be disposed with \c clang_VirtualFileOverlay_dispose().
+ */
+func NewVirtualFileOverlay() VirtualFileOverlay {
+	return VirtualFileOverlay{C.clang_VirtualFileOverlay_create(0)}
+}
+
+/**
+ * \brief Map an absolute virtual file path to an absolute real one.
+ * The virtual path must be canonicalized (not contain "."/"..").
+ */
+func (vfo VirtualFileOverlay) AddFileMapping(virtualPath, realPath string) error {
+	c_virtual := C.CString(virtualPath)
+	defer C.free(unsafe.Pointer(c_virtual))
+	c_real := C.CString(realPath)
+	defer C.free(unsafe.Pointer(c_real))
+
+	code := C.clang_VirtualFileOverlay_addFileMapping(vfo.c, c_virtual, c_real)
+	return newErrorCode(C.int(code))
+}
+
+/**
+ * \brief Set the case sensitivity for the \c CXVirtualFileOverlay object.
+ * The \c CXVirtualFileOverlay object is case-sensitive by default, this
+ * option can be used to override the default.
+ */
+func (vfo VirtualFileOverlay) SetCaseSensitivity(caseSensitive bool) error {
+	var c_sensitive C.int
+	if caseSensitive {
+		c_sensitive = 1
+	}
+	code := C.clang_VirtualFileOverlay_setCaseSensitivity(vfo.c, c_sensitive)
+	return newErrorCode(C.int(code))
+}
+
+/**
+ * \brief Write out the \c CXVirtualFileOverlay object to a char buffer.
+ *
+ * The returned buffer holds the YAML description of the overlay, suitable
+ * for Clang's \c -ivfsoverlay option.
+ */
+func (vfo VirtualFileOverlay) WriteToBuffer() ([]byte, error) {
+	var (
+		c_buf  *C.char
+		c_size C.unsigned
+	)
+	code := C.clang_VirtualFileOverlay_writeToBuffer(vfo.c, 0, &c_buf, &c_size)
+	if err := newErrorCode(C.int(code)); err != nil {
+		return nil, err
+	}
+	defer C.free(unsafe.Pointer(c_buf))
+	return C.GoBytes(unsafe.Pointer(c_buf), C.int(c_size)), nil
+}
+
+/**
+ * \brief Dispose a \c CXVirtualFileOverlay object.
+ */
+func (vfo VirtualFileOverlay) Dispose() {
+	C.clang_VirtualFileOverlay_dispose(vfo.c)
+}
+
+/**
+ * \brief Object encapsulating information about a module.map file.
+ */
+type ModuleMapDescriptor struct {
+	c C.CXModuleMapDescriptor
+}
+
+/**
+ * \brief Create a \c CXModuleMapDescriptor object.
+ * Must be disposed with \c clang_ModuleMapDescriptor_dispose().
+ */
+func NewModuleMapDescriptor() ModuleMapDescriptor {
+	return ModuleMapDescriptor{C.clang_ModuleMapDescriptor_create(0)}
+}
+
+/**
+ * \brief Sets the framework module name that the module.map describes.
+ */
+func (mmd ModuleMapDescriptor) SetFrameworkModuleName(name string) error {
+	c_name := C.CString(name)
+	defer C.free(unsafe.Pointer(c_name))
+
+	code := C.clang_ModuleMapDescriptor_setFrameworkModuleName(mmd.c, c_name)
+	return newErrorCode(C.int(code))
+}
+
+/**
+ * \brief Sets the umbrella header name that the module.map describes.
+ */
+func (mmd ModuleMapDescriptor) SetUmbrellaHeader(name string) error {
+	c_name := C.CString(name)
+	defer C.free(unsafe.Pointer(c_name))
+
+	code := C.clang_ModuleMapDescriptor_setUmbrellaHeader(mmd.c, c_name)
+	return newErrorCode(C.int(code))
+}
+
+/**
+ * \brief Write out the \c CXModuleMapDescriptor object to a char buffer.
+ */
+func (mmd ModuleMapDescriptor) WriteToBuffer() ([]byte, error) {
+	var (
+		c_buf  *C.char
+		c_size C.unsigned
+	)
+	code := C.clang_ModuleMapDescriptor_writeToBuffer(mmd.c, 0, &c_buf, &c_size)
+	if err := newErrorCode(C.int(code)); err != nil {
+		return nil, err
+	}
+	defer C.free(unsafe.Pointer(c_buf))
+	return C.GoBytes(unsafe.Pointer(c_buf), C.int(c_size)), nil
+}
+
+/**
+ * \brief Dispose a \c CXModuleMapDescriptor object.
+ */
+func (mmd ModuleMapDescriptor) Dispose() {
+	C.clang_ModuleMapDescriptor_dispose(mmd.c)
+}
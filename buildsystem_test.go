@@ -0,0 +1,57 @@
+package clang_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sbinet/go-clang"
+)
+
+func TestVirtualFileOverlay(t *testing.T) {
+	vfo := clang.NewVirtualFileOverlay()
+	defer vfo.Dispose()
+
+	if err := vfo.AddFileMapping("/virtual/dir/foo.h", "/real/dir/foo.h"); err != nil {
+		t.Fatalf("error adding file mapping: %v", err)
+	}
+	if err := vfo.AddFileMapping("relative/foo.h", "/real/dir/foo.h"); err == nil {
+		t.Errorf("expected an error mapping a relative virtual path")
+	}
+	if err := vfo.SetCaseSensitivity(false); err != nil {
+		t.Fatalf("error setting case sensitivity: %v", err)
+	}
+
+	buf, err := vfo.WriteToBuffer()
+	if err != nil {
+		t.Fatalf("error writing overlay: %v", err)
+	}
+	yaml := string(buf)
+	for _, want := range []string{"'case-sensitive': 'false'", "/virtual/dir", "foo.h", "/real/dir/foo.h"} {
+		if !strings.Contains(yaml, want) {
+			t.Errorf("expected %q in overlay:\n%s", want, yaml)
+		}
+	}
+}
+
+func TestModuleMapDescriptor(t *testing.T) {
+	mmd := clang.NewModuleMapDescriptor()
+	defer mmd.Dispose()
+
+	if err := mmd.SetFrameworkModuleName("Foo"); err != nil {
+		t.Fatalf("error setting framework module name: %v", err)
+	}
+	if err := mmd.SetUmbrellaHeader("Foo.h"); err != nil {
+		t.Fatalf("error setting umbrella header: %v", err)
+	}
+
+	buf, err := mmd.WriteToBuffer()
+	if err != nil {
+		t.Fatalf("error writing module map: %v", err)
+	}
+	modmap := string(buf)
+	for _, want := range []string{"framework module Foo", `umbrella header "Foo.h"`} {
+		if !strings.Contains(modmap, want) {
+			t.Errorf("expected %q in module map:\n%s", want, modmap)
+		}
+	}
+}
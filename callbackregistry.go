@@ -0,0 +1,84 @@
+package clang
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// callbackRegistry maps callback ids to the Go callbacks of the libclang
+// traversals in flight. The ids are handed to the C side as client data.
+//
+// get is called for every visited entity, possibly by many goroutines
+// traversing different translation units at once: it must not take a lock.
+// The registry is thus split into shards, each holding a copy-on-write map
+// of callbacks. get only loads the map of its shard atomically, while add
+// and remove (called once per traversal) copy the map of their shard under
+// the lock of that shard.
+//
+// The zero value is an empty registry ready to use.
+type callbackRegistry[T any] struct {
+	generation atomic.Uintptr
+	shards     [callbackRegistryShards]callbackRegistryShard[T]
+}
+
+const callbackRegistryShards = 64
+
+type callbackRegistryShard[T any] struct {
+	lock      sync.Mutex // serializes writers
+	callbacks atomic.Pointer[map[uintptr]T]
+
+	_ [48]byte // pad to a cache line, to avoid false sharing between shards
+}
+
+func (r *callbackRegistry[T]) shard(id uintptr) *callbackRegistryShard[T] {
+	return &r.shards[id%callbackRegistryShards]
+}
+
+func (r *callbackRegistry[T]) add(cb T) uintptr {
+	id := r.generation.Add(1)
+	s := r.shard(id)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var old map[uintptr]T
+	if p := s.callbacks.Load(); p != nil {
+		old = *p
+	}
+	m := make(map[uintptr]T, len(old)+1)
+	for k, v := range old {
+		m[k] = v
+	}
+	m[id] = cb
+	s.callbacks.Store(&m)
+	return id
+}
+
+func (r *callbackRegistry[T]) remove(id uintptr) {
+	s := r.shard(id)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	p := s.callbacks.Load()
+	if p == nil {
+		return
+	}
+	m := make(map[uintptr]T, len(*p))
+	for k, v := range *p {
+		if k != id {
+			m[k] = v
+		}
+	}
+	s.callbacks.Store(&m)
+}
+
+// get returns the callback registered under id, or the zero value of T.
+func (r *callbackRegistry[T]) get(id uintptr) T {
+	p := r.shard(id).callbacks.Load()
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return (*p)[id]
+}
@@ -0,0 +1,61 @@
+package clang
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestCallbackRegistry(t *testing.T) {
+	var (
+		r  callbackRegistry[CursorVisitor]
+		wg sync.WaitGroup
+	)
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				want := ChildVisitResult(i)
+				id := r.add(func(cursor, parent Cursor) ChildVisitResult { return want })
+				cb := r.get(id)
+				if cb == nil {
+					t.Errorf("missing callback %d", id)
+					return
+				}
+				if got := cb(Cursor{}, Cursor{}); got != want {
+					t.Errorf("callback %d: expected %d. got=%d", id, want, got)
+				}
+				r.remove(id)
+				if r.get(id) != nil {
+					t.Errorf("callback %d not removed", id)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// benchmarkCallbackRegistry simulates a traversal of 100 cursors.
+func benchmarkCallbackRegistry(r *callbackRegistry[CursorVisitor]) {
+	id := r.add(func(cursor, parent Cursor) ChildVisitResult { return CVR_Continue })
+	for i := 0; i < 100; i++ {
+		r.get(id)
+	}
+	r.remove(id)
+}
+
+func BenchmarkCallbackRegistry(b *testing.B) {
+	var r callbackRegistry[CursorVisitor]
+	for i := 0; i < b.N; i++ {
+		benchmarkCallbackRegistry(&r)
+	}
+}
+
+func BenchmarkCallbackRegistryParallel(b *testing.B) {
+	var r callbackRegistry[CursorVisitor]
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			benchmarkCallbackRegistry(&r)
+		}
+	})
+}
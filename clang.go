@@ -60,9 +60,26 @@ func NewIndex(excludeDeclarationsFromPCH, displayDiagnostics int) Index {
 // The index must not be destroyed until all of the translation units created
 // within that index have been destroyed.
 func (idx Index) Dispose() {
+	// wait for the parses abandoned by ParseContext.
+	abandonedOps.wait(unsafe.Pointer(idx.c))
 	C.clang_disposeIndex(idx.c)
 }
 
+/**
+ * \brief Enable/disable crash recovery.
+ *
+ * When crash recovery is enabled (the default), libclang tries to recover
+ * from crashes happening while parsing, in which case ParseErr returns
+ * Error_Crashed.
+ */
+func ToggleCrashRecovery(isEnabled bool) {
+	var c_enabled C.uint
+	if isEnabled {
+		c_enabled = 1
+	}
+	C.clang_toggleCrashRecovery(c_enabled)
+}
+
 /**
  * \brief Create a translation unit from an AST file (-emit-ast).
  */
@@ -73,6 +90,24 @@ func (idx Index) CreateTranslationUnit(fname string) TranslationUnit {
 	return TranslationUnit{o}
 }
 
+/**
+ * \brief Create a translation unit from an AST file (\c -emit-ast).
+ *
+ * LoadErr is the same as CreateTranslationUnit, but returns an ErrorCode
+ * describing why the AST file could not be loaded.
+ */
+func (idx Index) LoadErr(fname string) (TranslationUnit, error) {
+	cstr := C.CString(fname)
+	defer C.free(unsafe.Pointer(cstr))
+
+	var tu TranslationUnit
+	code := C.clang_createTranslationUnit2(idx.c, cstr, &tu.c)
+	if err := newErrorCode(C.int(code)); err != nil {
+		return TranslationUnit{}, err
+	}
+	return tu, nil
+}
+
 /**
  * \brief Return the CXTranslationUnit for a given source file and the provided
  * command line arguments one would pass to the compiler.
@@ -184,14 +219,40 @@ func (idx Index) CreateTranslationUnitFromSourceFile(fname string, args []string
  * is managed but not its compilation. This should be a bitwise OR of the
  * CXTranslationUnit_XXX flags.
  *
+ * \param opts additional parse options, such as WithFS.
+ *
  * \returns A new translation unit describing the parsed code and containing
  * any diagnostics produced by the compiler. If there is a failure from which
- * the compiler cannot recover, returns NULL.
+ * the compiler cannot recover, or if an option could not be applied,
+ * returns NULL.
  */
-func (idx Index) Parse(fname string, args []string, us UnsavedFiles, options TranslationUnitFlags) TranslationUnit {
+func (idx Index) Parse(fname string, args []string, us UnsavedFiles, options TranslationUnitFlags, opts ...ParseOption) TranslationUnit {
+	tu, _ := idx.ParseErr(fname, args, us, options, opts...)
+	return tu
+}
+
+/**
+ * \brief Parse the given source file and the translation unit corresponding
+ * to that file.
+ *
+ * This routine is the main entry point for the Clang C API, providing the
+ * ability to parse a source file into a translation unit that can then be
+ * queried by other functions in the API.
+ *
+ * ParseErr is the same as Parse, but returns an error describing why the
+ * translation unit could not be created. The error is an ErrorCode when
+ * libclang failed to parse the file.
+ */
+func (idx Index) ParseErr(fname string, args []string, us UnsavedFiles, options TranslationUnitFlags, opts ...ParseOption) (TranslationUnit, error) {
+	cfg, err := newParseConfig(args, us, opts)
+	if err != nil {
+		return TranslationUnit{}, err
+	}
+	args = cfg.args
+
 	var (
 		c_fname *C.char = nil
-		c_us            = us.to_c()
+		c_us            = cfg.us.to_c()
 	)
 	defer c_us.Dispose()
 	if fname != "" {
@@ -211,14 +272,18 @@ func (idx Index) Parse(fname string, args []string, us UnsavedFiles, options Tra
 	if len(args) > 0 {
 		c_args = &c_cmds[0]
 	}
-	o := C.clang_parseTranslationUnit(
+	var tu TranslationUnit
+	code := C.clang_parseTranslationUnit2(
 		idx.c,
 		c_fname,
 		c_args, c_nargs,
 		c_us.ptr(), C.uint(len(c_us)),
-		C.uint(options))
-	return TranslationUnit{o}
-
+		C.uint(options),
+		&tu.c)
+	if err := newErrorCode(C.int(code)); err != nil {
+		return TranslationUnit{}, err
+	}
+	return tu, nil
 }
 
 // EOF
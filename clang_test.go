@@ -1,6 +1,7 @@
 package clang_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/sbinet/go-clang"
@@ -45,4 +46,53 @@ func TestReparse(t *testing.T) {
 	}
 }
 
+func TestParseErr(t *testing.T) {
+	us := clang.UnsavedFiles{"hello.c": "int world(void);"}
+
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu, err := idx.ParseErr("hello.c", nil, us, 0)
+	if err != nil {
+		t.Fatalf("error parsing: %v", err)
+	}
+	defer tu.Dispose()
+	if !tu.IsValid() {
+		t.Fatal("TranslationUnit is not valid")
+	}
+
+	us["hello.c"] = "int world2(void);"
+	if err := tu.ReparseErr(us, 0); err != nil {
+		t.Fatalf("error reparsing: %v", err)
+	}
+
+	_, err = clang.Index{}.ParseErr("hello.c", nil, us, 0)
+	if !errors.Is(err, clang.Error_InvalidArguments) {
+		t.Errorf("expected Error_InvalidArguments. got=%v", err)
+	}
+
+	_, err = idx.ParseErr("testdata/does-not-exist.c", nil, nil, 0)
+	var code clang.ErrorCode
+	if !errors.As(err, &code) {
+		t.Errorf("expected an ErrorCode parsing a missing file. got=%v", err)
+	}
+}
+
+func TestLoadErr(t *testing.T) {
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+
+	tu, err := idx.LoadErr("testdata/does-not-exist.ast")
+	if err == nil {
+		tu.Dispose()
+		t.Fatal("expected an error loading a missing AST file")
+	}
+	var code clang.ErrorCode
+	if !errors.As(err, &code) {
+		t.Errorf("expected an ErrorCode. got=%T (%v)", err, err)
+	}
+	if tu.IsValid() {
+		t.Errorf("expected an invalid TranslationUnit")
+	}
+}
+
 // EOF
@@ -0,0 +1,73 @@
+package clang
+
+// #include <stdlib.h>
+// #include "go-clang.h"
+import "C"
+import (
+	"unsafe"
+)
+
+// CollectChildren returns the direct children of c.
+// If kinds are given, only the children of these kinds are returned.
+//
+// Contrary to Visit, the children are collected by libclang into a C buffer
+// in a single call, without calling back into Go for each cursor.
+func (c Cursor) CollectChildren(kinds ...CursorKind) []Cursor {
+	return c.collect(false, kinds)
+}
+
+// CollectDescendants returns all the descendants of c, in depth-first
+// pre-order.
+// If kinds are given, only the descendants of these kinds are returned:
+// the descendants of a filtered out cursor are still collected.
+//
+// Contrary to Visit, the descendants are collected by libclang into a C
+// buffer in a single call, without calling back into Go for each cursor.
+func (c Cursor) CollectDescendants(kinds ...CursorKind) []Cursor {
+	return c.collect(true, kinds)
+}
+
+func (c Cursor) collect(recurse bool, kinds []CursorKind) []Cursor {
+	var (
+		c_recurse C.int
+		c_kinds   *C.uchar
+		c_nkinds  C.uint
+	)
+	if recurse {
+		c_recurse = 1
+	}
+	if len(kinds) > 0 {
+		var max CursorKind
+		for _, k := range kinds {
+			if k > max {
+				max = k
+			}
+		}
+		set := make([]C.uchar, int(max)+1)
+		for _, k := range kinds {
+			set[k] = 1
+		}
+		c_kinds = &set[0]
+		c_nkinds = C.uint(len(set))
+	}
+
+	var (
+		c_cursors *C.CXCursor
+		c_len     C.uint
+	)
+	o := C._go_clang_collect_children(c.c, c_recurse, c_kinds, c_nkinds, &c_cursors, &c_len)
+	if o != 0 {
+		panic("clang: out of memory collecting cursors")
+	}
+	if c_len == 0 {
+		return nil
+	}
+	defer C.free(unsafe.Pointer(c_cursors))
+
+	cursors := unsafe.Slice(c_cursors, int(c_len))
+	ret := make([]Cursor, len(cursors))
+	for i := range cursors {
+		ret[i] = Cursor{cursors[i]}
+	}
+	return ret
+}
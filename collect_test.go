@@ -0,0 +1,157 @@
+package clang_test
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sbinet/go-clang"
+)
+
+func TestCollectDescendants(t *testing.T) {
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := parseWalkSrc(t, idx)
+	defer tu.Dispose()
+
+	root := tu.ToCursor()
+
+	var want []clang.Cursor
+	root.Visit(func(cursor, parent clang.Cursor) clang.ChildVisitResult {
+		want = append(want, cursor)
+		return clang.CVR_Recurse
+	})
+	got := root.CollectDescendants()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d descendants. got=%d", len(want), len(got))
+	}
+	for i := range got {
+		if !clang.EqualCursors(got[i], want[i]) {
+			t.Errorf("descendant #%d: expected %v. got=%v", i, want[i].Spelling(), got[i].Spelling())
+		}
+	}
+
+	var names []string
+	for _, c := range root.CollectDescendants(clang.CK_FieldDecl, clang.CK_ParmDecl) {
+		names = append(names, c.Spelling())
+	}
+	if strings.Join(names, " ") != "a b foo c a b" {
+		t.Errorf("expected [a b foo c a b]. got=%v", names)
+	}
+
+	names = names[:0]
+	for _, c := range root.CollectChildren(clang.CK_StructDecl) {
+		names = append(names, c.Spelling())
+	}
+	if strings.Join(names, " ") != "Foo Bar" {
+		t.Errorf("expected [Foo Bar]. got=%v", names)
+	}
+
+	if cs := root.CollectChildren(clang.CK_EnumDecl); cs != nil {
+		t.Errorf("expected no enum. got=%d cursors", len(cs))
+	}
+}
+
+func parseBenchSrc(b *testing.B, idx clang.Index) clang.TranslationUnit {
+	src := new(strings.Builder)
+	for i := 0; i < 1000; i++ {
+		fmt.Fprintf(src, "struct S%d { int a; float b; };\n", i)
+		fmt.Fprintf(src, "int f%d(struct S%d s, int x) { return s.a * x + (int)s.b; }\n", i, i)
+	}
+	tu := idx.Parse("bench.c", nil, clang.UnsavedFiles{"bench.c": src.String()}, 0)
+	if !tu.IsValid() {
+		b.Fatal("TranslationUnit is not valid")
+	}
+	return tu
+}
+
+func BenchmarkVisit(b *testing.B) {
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := parseBenchSrc(b, idx)
+	defer tu.Dispose()
+	root := tu.ToCursor()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var cs []clang.Cursor
+		root.Visit(func(cursor, parent clang.Cursor) clang.ChildVisitResult {
+			cs = append(cs, cursor)
+			return clang.CVR_Recurse
+		})
+	}
+}
+
+func BenchmarkCollectDescendants(b *testing.B) {
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := parseBenchSrc(b, idx)
+	defer tu.Dispose()
+	root := tu.ToCursor()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = root.CollectDescendants()
+	}
+}
+
+func BenchmarkVisitFiltered(b *testing.B) {
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := parseBenchSrc(b, idx)
+	defer tu.Dispose()
+	root := tu.ToCursor()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var cs []clang.Cursor
+		root.Visit(func(cursor, parent clang.Cursor) clang.ChildVisitResult {
+			if cursor.Kind() == clang.CK_FunctionDecl {
+				cs = append(cs, cursor)
+			}
+			return clang.CVR_Recurse
+		})
+	}
+}
+
+func BenchmarkCollectDescendantsFiltered(b *testing.B) {
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := parseBenchSrc(b, idx)
+	defer tu.Dispose()
+	root := tu.ToCursor()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = root.CollectDescendants(clang.CK_FunctionDecl)
+	}
+}
+
+// BenchmarkVisitParallel visits a different translation unit from each
+// goroutine.
+func BenchmarkVisitParallel(b *testing.B) {
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+
+	tus := make([]clang.TranslationUnit, runtime.GOMAXPROCS(0))
+	for i := range tus {
+		tus[i] = parseBenchSrc(b, idx)
+		defer tus[i].Dispose()
+	}
+
+	var next atomic.Int32
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := int(next.Add(1)-1) % len(tus)
+		root := tus[i].ToCursor()
+		for pb.Next() {
+			n := 0
+			root.Visit(func(cursor, parent clang.Cursor) clang.ChildVisitResult {
+				n++
+				return clang.CVR_Recurse
+			})
+		}
+	})
+}
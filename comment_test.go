@@ -0,0 +1,156 @@
+package clang_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sbinet/go-clang"
+)
+
+const commentSrc = `
+/**
+ * \brief Adds two integers.
+ *
+ * Uses \c plain arithmetic, see <a href="http://example.org/">here</a>.
+ *
+ * \param[in] a the first operand.
+ * \param b the second operand.
+ * \verbatim
+ * a + b
+ * \endverbatim
+ * \returns the sum.
+ */
+int add(int a, int b);
+`
+
+// parseComment parses src and calls fct with the parsed comment attached to
+// the declaration named name.
+func parseComment(t *testing.T, src, name string, fct func(cmt clang.Comment)) {
+	us := clang.UnsavedFiles{"comment.c": src}
+
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := idx.Parse("comment.c", nil, us, 0)
+	if !tu.IsValid() {
+		t.Fatal("TranslationUnit is not valid")
+	}
+	defer tu.Dispose()
+
+	var cmt clang.Comment
+	tu.ToCursor().Visit(func(cursor, parent clang.Cursor) clang.ChildVisitResult {
+		if cursor.Spelling() == name {
+			cmt = cursor.ParsedComment()
+			return clang.CVR_Break
+		}
+		return clang.CVR_Continue
+	})
+	if cmt.Kind() != clang.Comment_FullComment {
+		t.Fatalf("expected a FullComment. got=%v", cmt.Kind())
+	}
+	fct(cmt)
+}
+
+func TestComment(t *testing.T) {
+	parseComment(t, commentSrc, "add", func(cmt clang.Comment) {
+		testComment(t, cmt)
+	})
+}
+
+func testComment(t *testing.T, cmt clang.Comment) {
+	var (
+		params   []string
+		inline   []string
+		href     string
+		verbatim []string
+		blocks   []string
+	)
+	var walk func(c clang.Comment)
+	walk = func(c clang.Comment) {
+		switch c.Kind() {
+		case clang.Comment_ParamCommand:
+			params = append(params, c.ParamCommandComment_ParamName())
+			if c.ParamCommandComment_ParamName() == "a" {
+				if !c.ParamCommandComment_IsDirectionExplicit() {
+					t.Errorf("expected an explicit direction for 'a'")
+				}
+				if c.ParamCommandComment_Direction() != clang.CommentParamPassDirection_In {
+					t.Errorf("expected direction=In. got=%v", c.ParamCommandComment_Direction())
+				}
+			}
+			if !c.ParamCommandComment_IsParamIndexValid() {
+				t.Errorf("expected a valid param index for %q", c.ParamCommandComment_ParamName())
+			}
+		case clang.Comment_BlockCommand:
+			blocks = append(blocks, c.BlockCommandComment_CommandName())
+		case clang.Comment_InlineCommand:
+			for i := 0; i < c.InlineCommandComment_NumArgs(); i++ {
+				inline = append(inline, c.InlineCommandComment_ArgText(i))
+			}
+			if c.InlineCommandComment_RenderKind() != clang.CommentInlineCommandRenderKind_Monospaced {
+				t.Errorf("expected a monospaced inline command. got=%v", c.InlineCommandComment_RenderKind())
+			}
+		case clang.Comment_HTMLStartTag:
+			if c.HTMLTagComment_TagName() == "a" && c.HTMLStartTag_NumAttrs() == 1 {
+				href = c.HTMLStartTag_AttrValue(0)
+			}
+		case clang.Comment_VerbatimBlockLine:
+			verbatim = append(verbatim, c.VerbatimBlockLineComment_Text())
+		}
+		for i := 0; i < c.NumChildren(); i++ {
+			walk(c.Child(i))
+		}
+	}
+	walk(cmt)
+
+	if len(params) != 2 || params[0] != "a" || params[1] != "b" {
+		t.Errorf("expected params=[a b]. got=%v", params)
+	}
+	if len(inline) != 1 || inline[0] != "plain" {
+		t.Errorf("expected inline args=[plain]. got=%v", inline)
+	}
+	if href != "http://example.org/" {
+		t.Errorf("expected href=%q. got=%q", "http://example.org/", href)
+	}
+	if len(verbatim) != 1 {
+		t.Errorf("expected 1 verbatim line. got=%v", verbatim)
+	}
+	if len(blocks) != 2 || blocks[0] != "brief" || blocks[1] != "returns" {
+		t.Errorf("expected block commands=[brief returns]. got=%v", blocks)
+	}
+}
+
+func TestCommentRender(t *testing.T) {
+	parseComment(t, commentSrc, "add", func(cmt clang.Comment) {
+		if html := cmt.FullComment_AsHTML(); !strings.Contains(html, `class="para-brief"`) {
+			t.Errorf("expected a para-brief paragraph in HTML output:\n%s", html)
+		}
+		if xml := cmt.FullComment_AsXML(); !strings.Contains(xml, "<Name>add</Name>") {
+			t.Errorf("expected the function name in XML output:\n%s", xml)
+		}
+
+		md := cmt.Render(clang.MarkdownCommentRenderer())
+		for _, want := range []string{
+			"Adds two integers.",
+			"`plain`",
+			"- `a`: the first operand.",
+			"- `b`: the second operand.",
+			"```\n",
+			"**Returns:** the sum.",
+		} {
+			if !strings.Contains(md, want) {
+				t.Errorf("expected %q in Markdown output:\n%s", want, md)
+			}
+		}
+
+		n := 0
+		cmt.Render(clang.CommentRenderer{
+			clang.Comment_ParamCommand: func(c clang.Comment, body string) string {
+				n++
+				return body
+			},
+		})
+		if n != 2 {
+			t.Errorf("expected 2 rendered params. got=%d", n)
+		}
+	})
+}
@@ -119,6 +119,39 @@ const (
 	Comment_FullComment = C.CXComment_FullComment
 )
 
+func (ck CommentKind) String() string {
+	switch ck {
+	case Comment_Null:
+		return "Null"
+	case Comment_Text:
+		return "Text"
+	case Comment_InlineCommand:
+		return "InlineCommand"
+	case Comment_HTMLStartTag:
+		return "HTMLStartTag"
+	case Comment_HTMLEndTag:
+		return "HTMLEndTag"
+	case Comment_Paragraph:
+		return "Paragraph"
+	case Comment_BlockCommand:
+		return "BlockCommand"
+	case Comment_ParamCommand:
+		return "ParamCommand"
+	case Comment_TParamCommand:
+		return "TParamCommand"
+	case Comment_VerbatimBlockCommand:
+		return "VerbatimBlockCommand"
+	case Comment_VerbatimBlockLine:
+		return "VerbatimBlockLine"
+	case Comment_VerbatimLine:
+		return "VerbatimLine"
+	case Comment_FullComment:
+		return "FullComment"
+	default:
+		return "Invalid"
+	}
+}
+
 /**
  * \brief The most appropriate rendering mode for an inline command, chosen on
  * command semantics in Doxygen.
@@ -157,7 +190,7 @@ const (
 	/**
 	 * \brief The parameter is an input parameter.
 	 */
-	CommentParamPassDirection_In = C.CXCommentParamPassDirection_In
+	CommentParamPassDirection_In CommentParamPassDirection = C.CXCommentParamPassDirection_In
 
 	/**
 	 * \brief The parameter is an output parameter.
@@ -0,0 +1,110 @@
+package clang
+
+import (
+	"strings"
+)
+
+// CommentRenderFunc renders a single comment AST node.
+// body holds the already rendered children of the node.
+type CommentRenderFunc func(c Comment, body string) string
+
+// CommentRenderer holds the per-node templates used by Comment.Render.
+// Kinds without an entry are rendered with a default template which
+// outputs the text of leaf nodes and the body of the others.
+type CommentRenderer map[CommentKind]CommentRenderFunc
+
+// Render walks the comment tree depth-first and renders each node with the
+// template registered for its kind in r.
+func (c Comment) Render(r CommentRenderer) string {
+	var body strings.Builder
+	for i := 0; i < c.NumChildren(); i++ {
+		body.WriteString(c.Child(i).Render(r))
+	}
+	if fct := r[c.Kind()]; fct != nil {
+		return fct(c, body.String())
+	}
+	return renderCommentDefault(c, body.String())
+}
+
+func renderCommentDefault(c Comment, body string) string {
+	switch c.Kind() {
+	case Comment_Text:
+		o := c.TextComment()
+		if c.HasTrailingNewline() {
+			o += "\n"
+		}
+		return o
+	case Comment_InlineCommand:
+		args := make([]string, c.InlineCommandComment_NumArgs())
+		for i := range args {
+			args[i] = c.InlineCommandComment_ArgText(i)
+		}
+		o := strings.Join(args, " ")
+		if c.HasTrailingNewline() {
+			o += "\n"
+		}
+		return o
+	case Comment_HTMLStartTag, Comment_HTMLEndTag:
+		return c.HTMLTagComment_AsString()
+	case Comment_VerbatimBlockLine:
+		return c.VerbatimBlockLineComment_Text() + "\n"
+	case Comment_VerbatimLine:
+		return c.VerbatimLineComment_Text()
+	}
+	return body
+}
+
+// MarkdownCommentRenderer returns a CommentRenderer emitting Markdown.
+func MarkdownCommentRenderer() CommentRenderer {
+	return CommentRenderer{
+		Comment_InlineCommand: func(c Comment, body string) string {
+			o := renderCommentDefault(c, body)
+			nl := strings.HasSuffix(o, "\n")
+			o = strings.TrimSuffix(o, "\n")
+			switch c.InlineCommandComment_RenderKind() {
+			case CommentInlineCommandRenderKind_Bold:
+				o = "**" + o + "**"
+			case CommentInlineCommandRenderKind_Monospaced:
+				o = "`" + o + "`"
+			case CommentInlineCommandRenderKind_Emphasized:
+				o = "*" + o + "*"
+			}
+			if nl {
+				o += "\n"
+			}
+			return o
+		},
+		Comment_Paragraph: func(c Comment, body string) string {
+			if c.IsWhitespace() {
+				return ""
+			}
+			return strings.TrimSpace(body) + "\n\n"
+		},
+		Comment_BlockCommand: func(c Comment, body string) string {
+			body = strings.TrimSpace(body)
+			switch name := c.BlockCommandComment_CommandName(); name {
+			case "brief", "short":
+				return body + "\n\n"
+			case "return", "returns", "result":
+				return "**Returns:** " + body + "\n\n"
+			default:
+				return "**" + name + ":** " + body + "\n\n"
+			}
+		},
+		Comment_ParamCommand: func(c Comment, body string) string {
+			return "- `" + c.ParamCommandComment_ParamName() + "`: " + strings.TrimSpace(body) + "\n"
+		},
+		Comment_TParamCommand: func(c Comment, body string) string {
+			return "- `" + c.TParamCommandComment_ParamName() + "`: " + strings.TrimSpace(body) + "\n"
+		},
+		Comment_VerbatimBlockCommand: func(c Comment, body string) string {
+			return "```\n" + body + "```\n\n"
+		},
+		Comment_VerbatimLine: func(c Comment, body string) string {
+			return "`" + strings.TrimSpace(c.VerbatimLineComment_Text()) + "`\n\n"
+		},
+		Comment_FullComment: func(c Comment, body string) string {
+			return strings.TrimSpace(body) + "\n"
+		},
+	}
+}
@@ -114,6 +114,13 @@ func (db *CompilationDatabase) GetAllCompileCommands() CompileCommands {
 	return CompileCommands{c_cmds}
 }
 
+/**
+ * \brief Free the given CompileCommands
+ */
+func (cmds CompileCommands) Dispose() {
+	C.clang_CompileCommands_dispose(cmds.c)
+}
+
 /**
  * \brief Get the number of CompileCommand we have for a file
  */
@@ -0,0 +1,197 @@
+package clang
+
+import (
+	"context"
+	"sync"
+	"unsafe"
+)
+
+// abandonedOps tracks the libclang operations abandoned after their context
+// was cancelled, keyed by the index or translation unit they are using.
+// Such operations can not be interrupted: they run to completion in the
+// background, and the index or translation unit must not be disposed of
+// before that.
+var abandonedOps = abandonedRegistry{
+	ops: map[unsafe.Pointer]*abandonedOp{},
+}
+
+type abandonedRegistry struct {
+	lock sync.Mutex
+	ops  map[unsafe.Pointer]*abandonedOp
+}
+
+type abandonedOp struct {
+	n    int
+	done chan struct{}
+}
+
+// add registers an abandoned operation using key.
+// The returned function must be called once the operation has completed.
+func (r *abandonedRegistry) add(key unsafe.Pointer) func() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	op, ok := r.ops[key]
+	if !ok {
+		op = &abandonedOp{done: make(chan struct{})}
+		r.ops[key] = op
+	}
+	op.n++
+
+	return func() {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+
+		op.n--
+		if op.n == 0 {
+			close(op.done)
+			delete(r.ops, key)
+		}
+	}
+}
+
+// done returns a channel closed once all the abandoned operations using key
+// have completed, or nil if there are none.
+func (r *abandonedRegistry) done(key unsafe.Pointer) <-chan struct{} {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	op, ok := r.ops[key]
+	if !ok {
+		return nil
+	}
+	return op.done
+}
+
+// wait blocks until all the abandoned operations using key have completed.
+func (r *abandonedRegistry) wait(key unsafe.Pointer) {
+	if done := r.done(key); done != nil {
+		<-done
+	}
+}
+
+// waitContext is like wait, but returns ctx.Err() if ctx is cancelled first.
+func (r *abandonedRegistry) waitContext(ctx context.Context, key unsafe.Pointer) error {
+	done := r.done(key)
+	if done == nil {
+		return nil
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ParseContext is the same as ParseErr, but returns ctx.Err() as soon as
+// ctx is cancelled.
+// As libclang can not interrupt a parse, an abandoned parse keeps running
+// in the background and the resulting translation unit is disposed of once
+// it completes. Index.Dispose waits for abandoned parses to complete.
+func (idx Index) ParseContext(ctx context.Context, fname string, args []string, us UnsavedFiles, options TranslationUnitFlags, opts ...ParseOption) (TranslationUnit, error) {
+	if err := ctx.Err(); err != nil {
+		return TranslationUnit{}, err
+	}
+
+	// the caller may modify args and us once we return.
+	args = append([]string(nil), args...)
+	us = us.clone()
+
+	type result struct {
+		tu  TranslationUnit
+		err error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		tu, err := idx.ParseErr(fname, args, us, options, opts...)
+		ch <- result{tu, err}
+	}()
+
+	select {
+	case r := <-ch:
+		return r.tu, r.err
+	case <-ctx.Done():
+		release := abandonedOps.add(unsafe.Pointer(idx.c))
+		go func() {
+			defer release()
+			r := <-ch
+			if r.tu.IsValid() {
+				r.tu.Dispose()
+			}
+		}()
+		return TranslationUnit{}, ctx.Err()
+	}
+}
+
+// ReparseContext is the same as ReparseErr, but returns ctx.Err() as soon as
+// ctx is cancelled.
+// As libclang can not interrupt a reparse, an abandoned reparse keeps running
+// in the background: the translation unit is then left in an unspecified
+// state and should only be disposed of. TranslationUnit.Dispose waits for
+// the abandoned reparse to complete.
+func (tu TranslationUnit) ReparseContext(ctx context.Context, us UnsavedFiles, options TranslationUnitFlags) error {
+	key := unsafe.Pointer(tu.c)
+	if err := abandonedOps.waitContext(ctx, key); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	us = us.clone()
+	ch := make(chan error, 1)
+	go func() {
+		ch <- tu.ReparseErr(us, options)
+	}()
+
+	select {
+	case err := <-ch:
+		return err
+	case <-ctx.Done():
+		release := abandonedOps.add(key)
+		go func() {
+			defer release()
+			<-ch
+		}()
+		return ctx.Err()
+	}
+}
+
+// CompleteAtContext is the same as CompleteAt, but returns ctx.Err() as soon
+// as ctx is cancelled.
+// As libclang can not interrupt code completion, an abandoned completion
+// keeps running in the background and its results are disposed of once it
+// completes. Other operations on the translation unit started through a
+// *Context method, as well as TranslationUnit.Dispose, wait for the
+// abandoned completion to complete.
+func (tu TranslationUnit) CompleteAtContext(ctx context.Context, complete_filename string, complete_line, complete_column int, us UnsavedFiles, options CodeCompleteFlags) (CodeCompleteResults, error) {
+	key := unsafe.Pointer(tu.c)
+	if err := abandonedOps.waitContext(ctx, key); err != nil {
+		return CodeCompleteResults{}, err
+	}
+	if err := ctx.Err(); err != nil {
+		return CodeCompleteResults{}, err
+	}
+
+	us = us.clone()
+	ch := make(chan CodeCompleteResults, 1)
+	go func() {
+		ch <- tu.CompleteAt(complete_filename, complete_line, complete_column, us, options)
+	}()
+
+	select {
+	case res := <-ch:
+		return res, nil
+	case <-ctx.Done():
+		release := abandonedOps.add(key)
+		go func() {
+			defer release()
+			res := <-ch
+			if res.IsValid() {
+				res.Dispose()
+			}
+		}()
+		return CodeCompleteResults{}, ctx.Err()
+	}
+}
@@ -0,0 +1,79 @@
+package clang_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sbinet/go-clang"
+)
+
+func TestParseContext(t *testing.T) {
+	us := clang.UnsavedFiles{"ctx.c": "int f(void) { return 0; }\n"}
+
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := idx.ParseContext(ctx, "ctx.c", nil, us, 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled. got=%v", err)
+	}
+
+	tu, err := idx.ParseContext(context.Background(), "ctx.c", nil, us, 0)
+	if err != nil {
+		t.Fatalf("error parsing: %v", err)
+	}
+	defer tu.Dispose()
+
+	if err := tu.ReparseContext(context.Background(), us, 0); err != nil {
+		t.Fatalf("error reparsing: %v", err)
+	}
+	if err := tu.ReparseContext(ctx, us, 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled. got=%v", err)
+	}
+
+	res, err := tu.CompleteAtContext(context.Background(), "ctx.c", 1, 22, us, 0)
+	if err != nil {
+		t.Fatalf("error completing: %v", err)
+	}
+	if !res.IsValid() {
+		t.Errorf("CompleteResults are not valid")
+	} else {
+		res.Dispose()
+	}
+	if _, err := tu.CompleteAtContext(ctx, "ctx.c", 1, 22, us, 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled. got=%v", err)
+	}
+}
+
+func TestParseContextAbandon(t *testing.T) {
+	src := new(strings.Builder)
+	for i := 0; i < 20000; i++ {
+		fmt.Fprintf(src, "static int f%d(int x) { return x * %d + f%d(x); }\n", i, i, i)
+	}
+	us := clang.UnsavedFiles{"big.c": src.String()}
+
+	idx := clang.NewIndex(0, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	tu, err := idx.ParseContext(ctx, "big.c", nil, us, 0)
+	switch {
+	case err == nil:
+		// parse completed before the deadline.
+		tu.Dispose()
+	case errors.Is(err, context.DeadlineExceeded):
+		if tu.IsValid() {
+			t.Errorf("expected an invalid TranslationUnit")
+		}
+	default:
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// must wait for the abandoned parse, if any.
+	idx.Dispose()
+}
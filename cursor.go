@@ -4,7 +4,6 @@ package clang
 // #include "go-clang.h"
 import "C"
 import (
-	"sync"
 	"unsafe"
 )
 
@@ -795,7 +794,6 @@ type CursorVisitor func(cursor, parent Cursor) (status ChildVisitResult)
  * prematurely by the visitor returning \c CXChildVisit_Break.
  */
 func (c Cursor) Visit(visitor CursorVisitor) bool {
-	forceEscapeVisitor = &visitor
 	id := visitorCallbacks.add(visitor)
 	defer visitorCallbacks.remove(id)
 
@@ -806,44 +804,7 @@ func (c Cursor) Visit(visitor CursorVisitor) bool {
 	return true
 }
 
-type visitorCallbackRegistry struct {
-	lock       sync.Mutex
-	callbacks  map[uintptr]CursorVisitor
-	generation uintptr
-}
-
-var visitorCallbacks = visitorCallbackRegistry{
-	callbacks: map[uintptr]CursorVisitor{},
-}
-
-func (r *visitorCallbackRegistry) add(cb CursorVisitor) uintptr {
-	r.lock.Lock()
-	defer r.lock.Unlock()
-
-	r.generation++
-	r.callbacks[r.generation] = cb
-	return r.generation
-}
-
-func (r *visitorCallbackRegistry) remove(id uintptr) {
-	r.lock.Lock()
-	defer r.lock.Unlock()
-
-	delete(r.callbacks, id)
-}
-
-func (r *visitorCallbackRegistry) get(id uintptr) CursorVisitor {
-	r.lock.Lock()
-	defer r.lock.Unlock()
-
-	return r.callbacks[id]
-}
-
-// forceEscapeVisitor is write-only: to force compiler to escape the address
-// (else the address can become stale if the goroutine stack needs to grow
-// and is forced to move)
-// Explained by rsc in https://golang.org/issue/9125
-var forceEscapeVisitor *CursorVisitor
+var visitorCallbacks callbackRegistry[CursorVisitor]
 
 //export GoClangCursorVisitor
 func GoClangCursorVisitor(cursor, parent C.CXCursor, callback_id unsafe.Pointer) (status ChildVisitResult) {
@@ -1209,6 +1170,24 @@ func (c Cursor) SpecializedCursorTemplate() Cursor {
 	return Cursor{o}
 }
 
+// TemplateSpecialization returns the primary template c is a specialization
+// of, along with the template arguments of the specialization.
+// If c was instantiated from a class template partial specialization, the
+// primary template of that partial specialization is returned.
+// ok is false if c is not a template specialization.
+func (c Cursor) TemplateSpecialization() (primary Cursor, args []Type, ok bool) {
+	primary = c.SpecializedCursorTemplate()
+	if primary.IsNull() {
+		return primary, nil, false
+	}
+	if primary.Kind() == CK_ClassTemplatePartialSpecialization {
+		if tmpl := primary.SpecializedCursorTemplate(); !tmpl.IsNull() {
+			primary = tmpl
+		}
+	}
+	return primary, c.Type().TemplateArguments(), true
+}
+
 /**
  * \brief Given a cursor that references something else, return the source range
  * covering that reference.
@@ -1274,25 +1253,37 @@ const (
 	NR_WantSinglePiece = C.CXNameRange_WantSinglePiece
 )
 
-// TODO
-//
-// /**
-//  * \brief Find references of a declaration in a specific file.
-//  *
-//  * \param cursor pointing to a declaration or a reference of one.
-//  *
-//  * \param file to search for references.
-//  *
-//  * \param visitor callback that will receive pairs of CXCursor/CXSourceRange for
-//  * each reference found.
-//  * The CXSourceRange will point inside the file; if the reference is inside
-//  * a macro (and not a macro argument) the CXSourceRange will be invalid.
-//  *
-//  * \returns one of the CXResult enumerators.
-//  */
-// func (c Cursor) FindReferencesInFile(f File, visitor CursorAndRangeVisitor) Result {
-// 	return Result(C.clang_findReferencesInFile(c.c, f.c, visitor.c))
-// }
+/**
+ * \brief Find references of a declaration in a specific file.
+ *
+ * \param cursor pointing to a declaration or a reference of one.
+ *
+ * \param file to search for references.
+ *
+ * \param visitor callback that will receive pairs of CXCursor/CXSourceRange for
+ * each reference found.
+ * The CXSourceRange will point inside the file; if the reference is inside
+ * a macro (and not a macro argument) the CXSourceRange will be invalid.
+ *
+ * \returns one of the CXResult enumerators.
+ */
+func (c Cursor) VisitReferencesInFile(f File, visitor CursorAndRangeVisitor) Result {
+	id := cursorAndRangeCallbacks.add(visitor)
+	defer cursorAndRangeCallbacks.remove(id)
+
+	return Result(C._go_clang_find_references_in_file(c.c, f.c, C.uintptr_t(id)))
+}
+
+// ReferencesInFile returns the references of a declaration in a specific
+// file, as pairs of referencing cursors and source ranges.
+func (c Cursor) ReferencesInFile(f File) []CursorAndRange {
+	var refs []CursorAndRange
+	c.VisitReferencesInFile(f, func(cursor Cursor, r SourceRange) VisitorResult {
+		refs = append(refs, CursorAndRange{cursor, r})
+		return VR_Continue
+	})
+	return refs
+}
 
 // TODO
 //
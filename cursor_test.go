@@ -0,0 +1,157 @@
+package clang_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/sbinet/go-clang"
+)
+
+func TestReferencesInFile(t *testing.T) {
+	us := clang.UnsavedFiles{"refs.c": `
+int counter;
+void incr(void) { counter++; }
+void reset(void) { counter = 0; }
+int get(void) { return counter; }
+`}
+
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := idx.Parse("refs.c", nil, us, 0)
+	if !tu.IsValid() {
+		t.Fatal("TranslationUnit is not valid")
+	}
+	defer tu.Dispose()
+
+	var decl clang.Cursor
+	tu.ToCursor().Visit(func(cursor, parent clang.Cursor) clang.ChildVisitResult {
+		if cursor.Kind() == clang.CK_VarDecl && cursor.Spelling() == "counter" {
+			decl = cursor
+			return clang.CVR_Break
+		}
+		return clang.CVR_Continue
+	})
+	if decl.IsNull() {
+		t.Fatal("could not find the declaration of 'counter'")
+	}
+
+	f := tu.File("refs.c")
+	refs := decl.ReferencesInFile(f)
+	// the declaration itself + 3 uses.
+	if len(refs) != 4 {
+		t.Fatalf("expected 4 references to 'counter'. got=%d", len(refs))
+	}
+	for _, ref := range refs {
+		if !clang.EqualCursors(ref.Cursor.Referenced(), decl) {
+			t.Errorf("expected reference %v to refer to 'counter'", ref.Cursor.Kind())
+		}
+		if _, line, _, _ := ref.Range.Start().SpellingLocation(); line < 2 || line > 5 {
+			t.Errorf("unexpected reference at line %d", line)
+		}
+	}
+
+	n := 0
+	res := decl.VisitReferencesInFile(f, func(cursor clang.Cursor, r clang.SourceRange) clang.VisitorResult {
+		n++
+		return clang.VR_Break
+	})
+	if res != clang.Result_VisitBreak {
+		t.Errorf("expected result=%v. got=%v", clang.Result_VisitBreak, res)
+	}
+	if n != 1 {
+		t.Errorf("expected the visit to stop after 1 reference. got=%d", n)
+	}
+}
+
+func TestVisitErr(t *testing.T) {
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := parseWalkSrc(t, idx)
+	defer tu.Dispose()
+
+	root := tu.ToCursor()
+
+	n := 0
+	err := root.VisitErr(func(cursor, parent clang.Cursor) (clang.ChildVisitResult, error) {
+		n++
+		return clang.CVR_Recurse, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n == 0 {
+		t.Fatal("expected some cursors to be visited")
+	}
+
+	errFound := errors.New("found 'b'")
+	n = 0
+	err = root.VisitErr(func(cursor, parent clang.Cursor) (clang.ChildVisitResult, error) {
+		n++
+		if cursor.Spelling() == "b" {
+			return clang.CVR_Continue, errFound
+		}
+		return clang.CVR_Recurse, nil
+	})
+	if err != errFound {
+		t.Errorf("expected %v. got=%v", errFound, err)
+	}
+	// Foo, a, b
+	if n != 3 {
+		t.Errorf("expected traversal to stop after 3 cursors. got=%d", n)
+	}
+
+	err = root.VisitErr(func(cursor, parent clang.Cursor) (clang.ChildVisitResult, error) {
+		if cursor.Kind() == clang.CK_FieldDecl {
+			panic(errFound)
+		}
+		return clang.CVR_Recurse, nil
+	})
+	var perr *clang.VisitorPanicError
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected a VisitorPanicError. got=%v", err)
+	}
+	if !errors.Is(err, errFound) {
+		t.Errorf("expected panic value to be unwrapped")
+	}
+	if len(perr.Stack) == 0 {
+		t.Errorf("expected a stack trace")
+	}
+}
+
+func TestVisitConcurrent(t *testing.T) {
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+
+	const n = 8
+	tus := make([]clang.TranslationUnit, n)
+	for i := range tus {
+		tus[i] = parseWalkSrc(t, idx)
+		defer tus[i].Dispose()
+	}
+
+	count := func(root clang.Cursor) int {
+		n := 0
+		root.Visit(func(cursor, parent clang.Cursor) clang.ChildVisitResult {
+			n++
+			return clang.CVR_Recurse
+		})
+		return n
+	}
+	want := count(tus[0].ToCursor())
+
+	var wg sync.WaitGroup
+	for i := range tus {
+		wg.Add(1)
+		go func(root clang.Cursor) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if got := count(root); got != want {
+					t.Errorf("expected %d cursors. got=%d", want, got)
+					return
+				}
+			}
+		}(tus[i].ToCursor())
+	}
+	wg.Wait()
+}
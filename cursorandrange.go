@@ -0,0 +1,47 @@
+package clang
+
+// #include <stdlib.h>
+// #include "go-clang.h"
+import "C"
+import (
+	"unsafe"
+)
+
+/**
+ * \brief Describes how the traversal of a \c CXCursorAndRangeVisitor should
+ * proceed after visiting a particular cursor/range pair.
+ */
+type VisitorResult uint32
+
+const (
+	/**
+	 * \brief Terminates the traversal.
+	 */
+	VR_Break VisitorResult = C.CXVisit_Break
+
+	/**
+	 * \brief Continues the traversal with the next cursor/range pair.
+	 */
+	VR_Continue = C.CXVisit_Continue
+)
+
+// CursorAndRange pairs a cursor with a source range.
+type CursorAndRange struct {
+	Cursor Cursor
+	Range  SourceRange
+}
+
+// CursorAndRangeVisitor is invoked for each cursor/range pair found by a
+// search such as TranslationUnit.VisitIncludesInFile or
+// Cursor.VisitReferencesInFile.
+// The traversal is ended prematurely if the visitor returns VR_Break.
+type CursorAndRangeVisitor func(cursor Cursor, r SourceRange) VisitorResult
+
+var cursorAndRangeCallbacks callbackRegistry[CursorAndRangeVisitor]
+
+//export GoClangCursorAndRangeVisitor
+func GoClangCursorAndRangeVisitor(callback_id unsafe.Pointer, cursor C.CXCursor, r C.CXSourceRange) VisitorResult {
+	id := uintptr(callback_id)
+	cb := cursorAndRangeCallbacks.get(id)
+	return cb(Cursor{cursor}, SourceRange{r})
+}
@@ -0,0 +1,102 @@
+package clang
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// diagnosticJSON is the JSON schema of a Diagnostic.
+type diagnosticJSON struct {
+	Severity     string           `json:"severity"`
+	File         string           `json:"file"`
+	Line         uint             `json:"line"`
+	Column       uint             `json:"column"`
+	Message      string           `json:"message"`
+	Category     int              `json:"category"`
+	CategoryText string           `json:"category_text"`
+	Flag         string           `json:"flag"`
+	Ranges       []rangeJSON      `json:"ranges"`
+	FixIts       []fixItJSON      `json:"fixits"`
+	Notes        []diagnosticJSON `json:"notes"`
+}
+
+type locationJSON struct {
+	File   string `json:"file"`
+	Line   uint   `json:"line"`
+	Column uint   `json:"column"`
+}
+
+type rangeJSON struct {
+	Start locationJSON `json:"start"`
+	End   locationJSON `json:"end"`
+}
+
+type fixItJSON struct {
+	Range       rangeJSON `json:"range"`
+	Replacement string    `json:"replacement"`
+}
+
+func newLocationJSON(loc SourceLocation) locationJSON {
+	f, line, col, _ := loc.ExpansionLocation()
+	return locationJSON{
+		File:   f.Name(),
+		Line:   line,
+		Column: col,
+	}
+}
+
+func newRangeJSON(r SourceRange) rangeJSON {
+	return rangeJSON{
+		Start: newLocationJSON(r.Start()),
+		End:   newLocationJSON(r.End()),
+	}
+}
+
+func newDiagnosticJSON(d Diagnostic) diagnosticJSON {
+	loc := newLocationJSON(d.Location())
+	flag, _ := d.Option()
+	o := diagnosticJSON{
+		Severity:     strings.ToLower(d.Severity().String()),
+		File:         loc.File,
+		Line:         loc.Line,
+		Column:       loc.Column,
+		Message:      d.Spelling(),
+		Category:     d.Category(),
+		CategoryText: d.CategoryText(),
+		Flag:         flag,
+		Ranges:       []rangeJSON{},
+		FixIts:       []fixItJSON{},
+		Notes:        []diagnosticJSON{},
+	}
+	for _, r := range d.Ranges() {
+		o.Ranges = append(o.Ranges, newRangeJSON(r))
+	}
+	for _, fix := range d.FixIts() {
+		o.FixIts = append(o.FixIts, fixItJSON{
+			Range:       newRangeJSON(fix.ReplacementRange),
+			Replacement: fix.Data,
+		})
+	}
+	for _, note := range d.Children() {
+		o.Notes = append(o.Notes, newDiagnosticJSON(note))
+	}
+	return o
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// A diagnostic is encoded as a JSON object with the following fields:
+//   - "severity": one of "ignored", "note", "warning", "error" or "fatal",
+//   - "file", "line", "column": the expansion location of the diagnostic,
+//   - "message": the text of the diagnostic,
+//   - "category", "category_text": the category number and its text,
+//   - "flag": the command-line option which enabled the diagnostic (if any),
+//   - "ranges": an array of {"start", "end"} locations,
+//   - "fixits": an array of {"range", "replacement"} objects,
+//   - "notes": an array of the child diagnostics, with the same schema.
+//
+// Locations are encoded as {"file", "line", "column"} objects.
+// All fields are always present.
+func (d Diagnostic) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newDiagnosticJSON(d))
+}
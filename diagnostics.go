@@ -126,6 +126,31 @@ func (d Diagnostic) Option() (enable, disable string) {
 	return cx.String(), c_disable.String()
 }
 
+/**
+ * \brief Retrieve the category number for this diagnostic.
+ *
+ * Diagnostics can be categorized into groups along with other, related
+ * diagnostics (e.g., diagnostics under the same warning flag). This routine
+ * retrieves the category number for the given diagnostic.
+ *
+ * \returns The number of the category that contains this diagnostic, or zero
+ * if this diagnostic is uncategorized.
+ */
+func (d Diagnostic) Category() int {
+	return int(C.clang_getDiagnosticCategory(d.c))
+}
+
+/**
+ * \brief Retrieve the diagnostic category text for a given diagnostic.
+ *
+ * \returns The text of the given diagnostic category.
+ */
+func (d Diagnostic) CategoryText() string {
+	cx := cxstring{C.clang_getDiagnosticCategoryText(d.c)}
+	defer cx.Dispose()
+	return cx.String()
+}
+
 /**
  * \brief Retrieve a source range associated with the diagnostic.
  *
@@ -1,6 +1,11 @@
 package clang_test
 
 import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -31,3 +36,186 @@ func TestDiagnostics(t *testing.T) {
 		t.Errorf("Expected to find a diagnostic regarding _cgo_export.h")
 	}
 }
+
+func TestDiagnosticSet(t *testing.T) {
+	us := clang.UnsavedFiles{"redef.c": `
+int f(void) { return 0; }
+int f(void) { return 1; }
+`}
+
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := idx.Parse("redef.c", nil, us, 0)
+	if !tu.IsValid() {
+		t.Fatal("TranslationUnit is not valid")
+	}
+	defer tu.Dispose()
+
+	set := tu.DiagnosticSet()
+	defer set.Dispose()
+	if set.Len() != 1 {
+		t.Fatalf("expected 1 diagnostic. got=%d", set.Len())
+	}
+
+	diag := set.At(0)
+	if diag.Severity() != clang.Diagnostic_Error {
+		t.Errorf("expected an error. got=%v", diag.Severity())
+	}
+	notes := diag.Children()
+	if len(notes) != 1 {
+		t.Fatalf("expected 1 child note. got=%d", len(notes))
+	}
+	if notes[0].Severity() != clang.Diagnostic_Note {
+		t.Errorf("expected a note. got=%v", notes[0].Severity())
+	}
+	if !strings.Contains(notes[0].Spelling(), "previous definition") {
+		t.Errorf("unexpected note: %q", notes[0].Spelling())
+	}
+}
+
+func TestLoadDiagnosticsError(t *testing.T) {
+	invalid := filepath.Join(t.TempDir(), "invalid.dia")
+	err := os.WriteFile(invalid, []byte("not a serialized diagnostics file"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		fname string
+		want  clang.LoadDiagError
+	}{
+		{"testdata/not-there.dia", clang.LoadDiag_CannotLoad},
+		{invalid, clang.LoadDiag_InvalidFile},
+	} {
+		_, err := clang.LoadDiagnostics(test.fname)
+		if err == nil {
+			t.Errorf("expected an error loading %q", test.fname)
+			continue
+		}
+		var lerr *clang.LoadDiagnosticsError
+		if !errors.As(err, &lerr) {
+			t.Errorf("expected a *LoadDiagnosticsError. got=%T", err)
+			continue
+		}
+		if !errors.Is(err, test.want) {
+			t.Errorf("expected error %v loading %q. got=%v", test.want, test.fname, lerr.Err)
+		}
+	}
+
+	// errors.Is must match the constants themselves.
+	if _, err := clang.LoadDiagnostics("testdata/not-there.dia"); !errors.Is(err, clang.LoadDiag_CannotLoad) {
+		t.Errorf("expected errors.Is(err, LoadDiag_CannotLoad). got=%v", err)
+	}
+}
+
+func TestLoadDiagnostics(t *testing.T) {
+	cc, err := exec.LookPath("clang")
+	if err != nil {
+		t.Skip("clang not found in PATH: can not serialize diagnostics")
+	}
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "undecl.c")
+	dia := filepath.Join(dir, "undecl.dia")
+	err = os.WriteFile(src, []byte("int f(void) { return undeclared; }\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// clang fails on the error, but still writes the diagnostics.
+	exec.Command(cc, "-fsyntax-only", "--serialize-diagnostics", dia, src).Run()
+
+	set, err := clang.LoadDiagnostics(dia)
+	if err != nil {
+		t.Fatalf("error loading serialized diagnostics: %v", err)
+	}
+	defer set.Dispose()
+
+	if set.Len() != 1 {
+		t.Fatalf("expected 1 diagnostic. got=%d", set.Len())
+	}
+	diag := set.At(0)
+	if diag.Severity() != clang.Diagnostic_Error {
+		t.Errorf("expected an error. got=%v", diag.Severity())
+	}
+	if !strings.Contains(diag.Spelling(), "undeclared") {
+		t.Errorf("unexpected diagnostic: %q", diag.Spelling())
+	}
+	if f, line, _, _ := diag.Location().SpellingLocation(); f.Name() != src || line != 1 {
+		t.Errorf("expected the error on %s:1. got=%s:%d", src, f.Name(), line)
+	}
+
+	for _, i := range []int{-1, set.Len()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected At(%d) to panic", i)
+				}
+			}()
+			set.At(i)
+		}()
+	}
+}
+
+func TestDiagnosticJSON(t *testing.T) {
+	us := clang.UnsavedFiles{"redef.c": `
+int f(void) { return 0; }
+int f(void) { return 1; }
+`}
+
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := idx.Parse("redef.c", nil, us, 0)
+	if !tu.IsValid() {
+		t.Fatal("TranslationUnit is not valid")
+	}
+	defer tu.Dispose()
+
+	diags := tu.Diagnostics()
+	defer diags.Dispose()
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic. got=%d", len(diags))
+	}
+	if diags[0].CategoryText() != "Semantic Issue" {
+		t.Errorf("expected category 'Semantic Issue'. got=%q", diags[0].CategoryText())
+	}
+
+	buf, err := json.Marshal(diags)
+	if err != nil {
+		t.Fatalf("error marshaling diagnostics: %v", err)
+	}
+
+	var got []struct {
+		Severity     string `json:"severity"`
+		File         string `json:"file"`
+		Line         uint   `json:"line"`
+		Column       uint   `json:"column"`
+		Category     int    `json:"category"`
+		CategoryText string `json:"category_text"`
+		Ranges       []any  `json:"ranges"`
+		FixIts       []any  `json:"fixits"`
+		Notes        []struct {
+			Severity string `json:"severity"`
+			Line     uint   `json:"line"`
+		} `json:"notes"`
+	}
+	err = json.Unmarshal(buf, &got)
+	if err != nil {
+		t.Fatalf("error unmarshaling diagnostics: %v\n%s", err, buf)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected 1 diagnostic. got=%d", len(got))
+	}
+	d := got[0]
+	if d.Severity != "error" || d.File != "redef.c" || d.Line != 3 || d.Column == 0 {
+		t.Errorf("unexpected diagnostic: %s", buf)
+	}
+	if d.Category == 0 || d.CategoryText != "Semantic Issue" {
+		t.Errorf("unexpected category: %s", buf)
+	}
+	if d.Ranges == nil || d.FixIts == nil {
+		t.Errorf("expected ranges and fixits arrays: %s", buf)
+	}
+	if len(d.Notes) != 1 || d.Notes[0].Severity != "note" || d.Notes[0].Line != 2 {
+		t.Errorf("unexpected notes: %s", buf)
+	}
+}
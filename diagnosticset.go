@@ -0,0 +1,175 @@
+package clang
+
+// #include <stdlib.h>
+// #include "go-clang.h"
+import "C"
+import (
+	"fmt"
+	"unsafe"
+)
+
+/**
+ * \brief A group of CXDiagnostics.
+ */
+type DiagnosticSet struct {
+	c C.CXDiagnosticSet
+}
+
+/**
+ * \brief Describes the kind of error that occurred (if any) in a call to
+ * \c clang_loadDiagnostics.
+ */
+type LoadDiagError int
+
+const (
+	/**
+	 * \brief Indicates that no error occurred.
+	 */
+	LoadDiag_None LoadDiagError = C.CXLoadDiag_None
+
+	/**
+	 * \brief Indicates that an unknown error occurred while attempting to
+	 * deserialize diagnostics.
+	 */
+	LoadDiag_Unknown LoadDiagError = C.CXLoadDiag_Unknown
+
+	/**
+	 * \brief Indicates that the file containing the serialized diagnostics
+	 * could not be opened.
+	 */
+	LoadDiag_CannotLoad LoadDiagError = C.CXLoadDiag_CannotLoad
+
+	/**
+	 * \brief Indicates that the serialized diagnostics file is invalid or
+	 * corrupt.
+	 */
+	LoadDiag_InvalidFile LoadDiagError = C.CXLoadDiag_InvalidFile
+)
+
+func (err LoadDiagError) Error() string {
+	switch err {
+	case LoadDiag_None:
+		return "go-clang: no error"
+	case LoadDiag_Unknown:
+		return "go-clang: unknown error while loading diagnostics"
+	case LoadDiag_CannotLoad:
+		return "go-clang: can not load diagnostics file"
+	case LoadDiag_InvalidFile:
+		return "go-clang: invalid diagnostics file"
+	default:
+		return fmt.Sprintf("go-clang: unknown load-diagnostics error (%d)", int(err))
+	}
+}
+
+// LoadDiagnosticsError is returned by LoadDiagnostics when a serialized
+// diagnostics file could not be loaded.
+type LoadDiagnosticsError struct {
+	File string        // name of the serialized diagnostics file
+	Err  LoadDiagError // kind of the error
+	Msg  string        // error string reported by libclang
+}
+
+func (err *LoadDiagnosticsError) Error() string {
+	if err.Msg == "" {
+		return fmt.Sprintf("%v (%s)", err.Err, err.File)
+	}
+	return fmt.Sprintf("%v (%s): %s", err.Err, err.File, err.Msg)
+}
+
+func (err *LoadDiagnosticsError) Unwrap() error {
+	return err.Err
+}
+
+/**
+ * \brief Deserialize a set of diagnostics from a Clang diagnostics bitcode
+ * file, as produced with \c --serialize-diagnostics.
+ *
+ * \returns A loaded CXDiagnosticSet if successful, and a
+ * *LoadDiagnosticsError otherwise. These diagnostics should be released
+ * using DiagnosticSet.Dispose.
+ */
+func LoadDiagnostics(fname string) (DiagnosticSet, error) {
+	c_fname := C.CString(fname)
+	defer C.free(unsafe.Pointer(c_fname))
+
+	var (
+		c_err C.enum_CXLoadDiag_Error
+		c_msg cxstring
+	)
+	o := C.clang_loadDiagnostics(c_fname, &c_err, &c_msg.c)
+	defer c_msg.Dispose()
+	if o == nil || c_err != C.CXLoadDiag_None {
+		err := LoadDiagError(c_err)
+		if err == LoadDiag_None {
+			err = LoadDiag_Unknown
+		}
+		return DiagnosticSet{}, &LoadDiagnosticsError{
+			File: fname,
+			Err:  err,
+			Msg:  c_msg.String(),
+		}
+	}
+	return DiagnosticSet{o}, nil
+}
+
+/**
+ * \brief Retrieve the complete set of diagnostics associated with a
+ *        translation unit.
+ *
+ * The returned set must be released using DiagnosticSet.Dispose.
+ */
+func (tu TranslationUnit) DiagnosticSet() DiagnosticSet {
+	return DiagnosticSet{C.clang_getDiagnosticSetFromTU(tu.c)}
+}
+
+/**
+ * \brief Release a CXDiagnosticSet and all of its contained diagnostics.
+ */
+func (ds DiagnosticSet) Dispose() {
+	C.clang_disposeDiagnosticSet(ds.c)
+}
+
+/**
+ * \brief Determine the number of diagnostics in a CXDiagnosticSet.
+ */
+func (ds DiagnosticSet) Len() int {
+	return int(C.clang_getNumDiagnosticsInSet(ds.c))
+}
+
+/**
+ * \brief Retrieve a diagnostic associated with the given CXDiagnosticSet.
+ *
+ * \param Index the zero-based diagnostic number to retrieve.
+ */
+func (ds DiagnosticSet) At(i int) Diagnostic {
+	if i < 0 || i >= ds.Len() {
+		panic("clang: index out of range")
+	}
+	return Diagnostic{C.clang_getDiagnosticInSet(ds.c, C.uint(i))}
+}
+
+// Diagnostics returns all the diagnostics of the set.
+// The diagnostics are owned by the set and are released with it.
+func (ds DiagnosticSet) Diagnostics() Diagnostics {
+	ret := make(Diagnostics, ds.Len())
+	for i := range ret {
+		ret[i].c = C.clang_getDiagnosticInSet(ds.c, C.uint(i))
+	}
+	return ret
+}
+
+/**
+ * \brief Retrieve the child diagnostics of a CXDiagnostic.
+ *
+ * This CXDiagnosticSet does not need to be released by
+ * clang_disposeDiagnosticSet.
+ */
+func (d Diagnostic) ChildDiagnostics() DiagnosticSet {
+	return DiagnosticSet{C.clang_getChildDiagnostics(d.c)}
+}
+
+// Children returns the child diagnostics (e.g. notes such as
+// "previous declaration is here") attached to the diagnostic.
+func (d Diagnostic) Children() Diagnostics {
+	return d.ChildDiagnostics().Diagnostics()
+}
@@ -0,0 +1,74 @@
+package clang
+
+// #include <stdlib.h>
+// #include "go-clang.h"
+// #include "clang-c/CXErrorCode.h"
+import "C"
+import (
+	"fmt"
+)
+
+/**
+ * \brief Error codes returned by libclang routines.
+ *
+ * Zero (\c CXError_Success) is the only error code indicating success.  Other
+ * error codes, including not yet assigned non-zero values, indicate errors.
+ */
+type ErrorCode int
+
+const (
+	/**
+	 * \brief No error.
+	 */
+	Error_Success ErrorCode = C.CXError_Success
+
+	/**
+	 * \brief A generic error code, no further details are available.
+	 *
+	 * Errors of this kind can get their own specific error codes in future
+	 * libclang versions.
+	 */
+	Error_Failure ErrorCode = C.CXError_Failure
+
+	/**
+	 * \brief libclang crashed while performing the requested operation.
+	 */
+	Error_Crashed ErrorCode = C.CXError_Crashed
+
+	/**
+	 * \brief The function detected that the arguments violate the function
+	 * contract.
+	 */
+	Error_InvalidArguments ErrorCode = C.CXError_InvalidArguments
+
+	/**
+	 * \brief An AST deserialization error has occurred.
+	 */
+	Error_ASTReadError ErrorCode = C.CXError_ASTReadError
+)
+
+func (err ErrorCode) Error() string {
+	switch err {
+	case Error_Success:
+		return "go-clang: success"
+	case Error_Failure:
+		return "go-clang: failure"
+	case Error_Crashed:
+		return "go-clang: libclang crashed"
+	case Error_InvalidArguments:
+		return "go-clang: invalid arguments"
+	case Error_ASTReadError:
+		return "go-clang: AST deserialization error"
+	default:
+		return fmt.Sprintf("go-clang: unknown error code (%d)", int(err))
+	}
+}
+
+// newErrorCode returns nil for CXError_Success and the matching ErrorCode
+// otherwise.
+func newErrorCode(code C.int) error {
+	if code == C.int(Error_Success) {
+		return nil
+	}
+	return ErrorCode(code)
+}
@@ -0,0 +1,22 @@
+// go-clang-worker parses a translation unit on behalf of a parent process,
+// isolating it from libclang crashes.
+//
+// go-clang-worker reads a gob-encoded isolate.Request from its standard
+// input and writes the gob-encoded isolate.Response to its standard output.
+// It is run by isolate.Parser and is not meant to be run directly.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sbinet/go-clang/isolate"
+)
+
+func main() {
+	err := isolate.Serve(os.Stdin, os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "**error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -30,8 +30,52 @@ _goclang_get_platform_availability_at(CXPlatformAvailability* array, int idx) {
   return array[idx];
 }
 
+inline static
+CXSourceLocation _go_clang_sourcelocation_at(CXSourceLocation *locs, int idx) {
+  return locs[idx];
+}
+
+inline static
+CXSourceRange _go_clang_sourcerange_at(CXSourceRange *ranges, int idx) {
+  return ranges[idx];
+}
+
+inline static
+CXTUResourceUsageEntry
+_go_clang_tu_resource_usage_entry_at(CXTUResourceUsageEntry *entries, int idx) {
+  return entries[idx];
+}
+
 unsigned _go_clang_visit_children(CXCursor c, uintptr_t callback_id);
 
+int _go_clang_collect_children(CXCursor c, int recurse,
+                               const unsigned char *kinds, unsigned nkinds,
+                               CXCursor **out_cursors, unsigned *out_len);
+
+void _go_clang_get_inclusions(CXTranslationUnit tu, uintptr_t callback_id);
+
+CXResult _go_clang_find_includes_in_file(CXTranslationUnit tu, CXFile file,
+                                         uintptr_t callback_id);
+
+CXResult _go_clang_find_references_in_file(CXCursor c, CXFile file,
+                                           uintptr_t callback_id);
+
+int _go_clang_index_source_file(CXIndexAction action,
+                                uintptr_t callback_id,
+                                unsigned index_options,
+                                const char *source_filename,
+                                const char * const *command_line_args,
+                                int num_command_line_args,
+                                struct CXUnsavedFile *unsaved_files,
+                                unsigned num_unsaved_files,
+                                CXTranslationUnit *out_TU,
+                                unsigned TU_options);
+
+int _go_clang_index_translation_unit(CXIndexAction action,
+                                     uintptr_t callback_id,
+                                     unsigned index_options,
+                                     CXTranslationUnit tu);
+
 CXPlatformAvailability
 _goclang_get_platform_availability_at(CXPlatformAvailability* array, int idx);
 
@@ -0,0 +1,537 @@
+package clang
+
+// #include <stdlib.h>
+// #include "go-clang.h"
+import "C"
+import (
+	"unsafe"
+)
+
+// IdxEntityKind describes the kind of an entity reported by the indexer.
+type IdxEntityKind int
+
+const (
+	IdxEntity_Unexposed             IdxEntityKind = C.CXIdxEntity_Unexposed
+	IdxEntity_Typedef                             = C.CXIdxEntity_Typedef
+	IdxEntity_Function                            = C.CXIdxEntity_Function
+	IdxEntity_Variable                            = C.CXIdxEntity_Variable
+	IdxEntity_Field                               = C.CXIdxEntity_Field
+	IdxEntity_EnumConstant                        = C.CXIdxEntity_EnumConstant
+	IdxEntity_ObjCClass                           = C.CXIdxEntity_ObjCClass
+	IdxEntity_ObjCProtocol                        = C.CXIdxEntity_ObjCProtocol
+	IdxEntity_ObjCCategory                        = C.CXIdxEntity_ObjCCategory
+	IdxEntity_ObjCInstanceMethod                  = C.CXIdxEntity_ObjCInstanceMethod
+	IdxEntity_ObjCClassMethod                     = C.CXIdxEntity_ObjCClassMethod
+	IdxEntity_ObjCProperty                        = C.CXIdxEntity_ObjCProperty
+	IdxEntity_ObjCIvar                            = C.CXIdxEntity_ObjCIvar
+	IdxEntity_Enum                                = C.CXIdxEntity_Enum
+	IdxEntity_Struct                              = C.CXIdxEntity_Struct
+	IdxEntity_Union                               = C.CXIdxEntity_Union
+	IdxEntity_CXXClass                            = C.CXIdxEntity_CXXClass
+	IdxEntity_CXXNamespace                        = C.CXIdxEntity_CXXNamespace
+	IdxEntity_CXXNamespaceAlias                   = C.CXIdxEntity_CXXNamespaceAlias
+	IdxEntity_CXXStaticVariable                   = C.CXIdxEntity_CXXStaticVariable
+	IdxEntity_CXXStaticMethod                     = C.CXIdxEntity_CXXStaticMethod
+	IdxEntity_CXXInstanceMethod                   = C.CXIdxEntity_CXXInstanceMethod
+	IdxEntity_CXXConstructor                      = C.CXIdxEntity_CXXConstructor
+	IdxEntity_CXXDestructor                       = C.CXIdxEntity_CXXDestructor
+	IdxEntity_CXXConversionFunction               = C.CXIdxEntity_CXXConversionFunction
+	IdxEntity_CXXTypeAlias                        = C.CXIdxEntity_CXXTypeAlias
+	IdxEntity_CXXInterface                        = C.CXIdxEntity_CXXInterface
+)
+
+// IsObjCContainer returns true if the entity kind is an Objective-C
+// container (class, protocol or category).
+func (k IdxEntityKind) IsObjCContainer() bool {
+	o := C.clang_index_isEntityObjCContainerKind(C.CXIdxEntityKind(k))
+	if o != 0 {
+		return true
+	}
+	return false
+}
+
+// IdxEntityLanguage describes the language of an entity reported by the indexer.
+type IdxEntityLanguage int
+
+const (
+	IdxEntityLang_None IdxEntityLanguage = C.CXIdxEntityLang_None
+	IdxEntityLang_C                      = C.CXIdxEntityLang_C
+	IdxEntityLang_ObjC                   = C.CXIdxEntityLang_ObjC
+	IdxEntityLang_CXX                    = C.CXIdxEntityLang_CXX
+)
+
+/**
+ * \brief Extra C++ template information for an entity. This can apply to:
+ * CXIdxEntity_Function
+ * CXIdxEntity_CXXClass
+ * CXIdxEntity_CXXStaticMethod
+ * CXIdxEntity_CXXInstanceMethod
+ * CXIdxEntity_CXXConstructor
+ * CXIdxEntity_CXXConversionFunction
+ * CXIdxEntity_CXXTypeAlias
+ */
+type IdxEntityCXXTemplateKind int
+
+const (
+	IdxEntity_NonTemplate                   IdxEntityCXXTemplateKind = C.CXIdxEntity_NonTemplate
+	IdxEntity_Template                                               = C.CXIdxEntity_Template
+	IdxEntity_TemplatePartialSpecialization                          = C.CXIdxEntity_TemplatePartialSpecialization
+	IdxEntity_TemplateSpecialization                                 = C.CXIdxEntity_TemplateSpecialization
+)
+
+/**
+ * \brief Data for IndexerCallbacks#indexEntityReference.
+ */
+type IdxEntityRefKind int
+
+const (
+	/**
+	 * \brief The entity is referenced directly in user's code.
+	 */
+	IdxEntityRef_Direct IdxEntityRefKind = C.CXIdxEntityRef_Direct
+
+	/**
+	 * \brief An implicit reference, e.g. a reference of an Objective-C method
+	 * via the dot syntax.
+	 */
+	IdxEntityRef_Implicit = C.CXIdxEntityRef_Implicit
+)
+
+// IdxDeclInfoFlags holds extra information about an indexed declaration.
+type IdxDeclInfoFlags uint32
+
+const (
+	IdxDeclFlag_Skipped IdxDeclInfoFlags = C.CXIdxDeclFlag_Skipped
+)
+
+// IndexOptFlags holds the options controlling an indexing session.
+type IndexOptFlags uint32
+
+const (
+	/**
+	 * \brief Used to indicate that no special indexing options are needed.
+	 */
+	IndexOpt_None IndexOptFlags = C.CXIndexOpt_None
+
+	/**
+	 * \brief Used to indicate that IndexerCallbacks#indexEntityReference should
+	 * be invoked for only one reference of an entity per source file that does
+	 * not also include a declaration/definition of the entity.
+	 */
+	IndexOpt_SuppressRedundantRefs = C.CXIndexOpt_SuppressRedundantRefs
+
+	/**
+	 * \brief Function-local symbols should be indexed. If this is not set
+	 * function-local symbols will be ignored.
+	 */
+	IndexOpt_IndexFunctionLocalSymbols = C.CXIndexOpt_IndexFunctionLocalSymbols
+
+	/**
+	 * \brief Implicit function/class template instantiations should be indexed.
+	 * If this is not set, implicit instantiations will be ignored.
+	 */
+	IndexOpt_IndexImplicitTemplateInstantiations = C.CXIndexOpt_IndexImplicitTemplateInstantiations
+
+	/**
+	 * \brief Suppress all compiler warnings when parsing for indexing.
+	 */
+	IndexOpt_SuppressWarnings = C.CXIndexOpt_SuppressWarnings
+
+	/**
+	 * \brief Skip a function/method body that was already parsed during an
+	 * indexing session associated with a \c CXIndexAction object.
+	 * Bodies in system headers are always skipped.
+	 */
+	IndexOpt_SkipParsedBodiesInSession = C.CXIndexOpt_SkipParsedBodiesInSession
+)
+
+// IdxLoc is a source location reported by the indexer.
+type IdxLoc struct {
+	c C.CXIdxLoc
+}
+
+/**
+ * \brief Retrieve the file, line, column, and offset represented by
+ * the given CXIdxLoc.
+ *
+ * If the location refers into a macro expansion, retrieves the
+ * location of the macro expansion and if it refers into a macro argument
+ * retrieves the location of the argument.
+ */
+func (l IdxLoc) FileLocation() (f File, line, column, offset uint) {
+	cline := C.uint(0)
+	ccol := C.uint(0)
+	coff := C.uint(0)
+	C.clang_indexLoc_getFileLocation(l.c, nil, &f.c, &cline, &ccol, &coff)
+	line = uint(cline)
+	column = uint(ccol)
+	offset = uint(coff)
+	return
+}
+
+/**
+ * \brief Retrieve the CXSourceLocation represented by the given CXIdxLoc.
+ */
+func (l IdxLoc) SourceLocation() SourceLocation {
+	return SourceLocation{C.clang_indexLoc_getCXSourceLocation(l.c)}
+}
+
+// IdxEntityInfo describes an entity reported by the indexer.
+type IdxEntityInfo struct {
+	Kind         IdxEntityKind
+	TemplateKind IdxEntityCXXTemplateKind
+	Lang         IdxEntityLanguage
+	Name         string
+	USR          string
+	Cursor       Cursor
+}
+
+func newIdxEntityInfo(c *C.CXIdxEntityInfo) *IdxEntityInfo {
+	if c == nil {
+		return nil
+	}
+	return &IdxEntityInfo{
+		Kind:         IdxEntityKind(c.kind),
+		TemplateKind: IdxEntityCXXTemplateKind(c.templateKind),
+		Lang:         IdxEntityLanguage(c.lang),
+		Name:         C.GoString(c.name),
+		USR:          C.GoString(c.USR),
+		Cursor:       Cursor{c.cursor},
+	}
+}
+
+// IdxContainerInfo describes the container of a declaration or reference.
+type IdxContainerInfo struct {
+	Cursor Cursor
+}
+
+func newIdxContainerInfo(c *C.CXIdxContainerInfo) *IdxContainerInfo {
+	if c == nil {
+		return nil
+	}
+	return &IdxContainerInfo{Cursor{c.cursor}}
+}
+
+// IdxDeclInfo describes a declaration reported by the indexer.
+type IdxDeclInfo struct {
+	EntityInfo        *IdxEntityInfo
+	Cursor            Cursor
+	Loc               IdxLoc
+	SemanticContainer *IdxContainerInfo
+
+	// LexicalContainer is generally the same as SemanticContainer but can
+	// be different in cases like out-of-line C++ member functions.
+	LexicalContainer *IdxContainerInfo
+
+	IsRedeclaration bool
+	IsDefinition    bool
+	IsContainer     bool
+	DeclAsContainer *IdxContainerInfo
+
+	// IsImplicit reports whether the declaration exists in code or was
+	// created implicitly by the compiler, e.g. implicit Objective-C methods
+	// for properties.
+	IsImplicit bool
+
+	Flags IdxDeclInfoFlags
+}
+
+// IdxEntityRefInfo describes a reference to an entity reported by the indexer.
+type IdxEntityRefInfo struct {
+	Kind IdxEntityRefKind
+
+	// Cursor is the reference cursor.
+	Cursor Cursor
+	Loc    IdxLoc
+
+	// ReferencedEntity is the entity that gets referenced.
+	ReferencedEntity *IdxEntityInfo
+
+	// ParentEntity is the immediate "parent" of the reference.
+	// For example, in:
+	//   Foo *var;
+	// the parent of reference of type 'Foo' is the variable 'var'.
+	// For references inside statement bodies of functions/methods,
+	// the ParentEntity will be the function/method.
+	ParentEntity *IdxEntityInfo
+
+	// Container is the lexical container context of the reference.
+	Container *IdxContainerInfo
+}
+
+// IdxIncludedFileInfo describes an #include/#import directive.
+type IdxIncludedFileInfo struct {
+	// HashLoc is the location of '#' in the #include/#import directive.
+	HashLoc IdxLoc
+
+	// Filename is the filename as written in the #include/#import directive.
+	Filename string
+
+	// File is the actual file that the #include/#import directive resolved to.
+	File File
+
+	IsImport bool
+	IsAngled bool
+
+	// IsModuleImport is true if the directive was automatically turned into
+	// a module import.
+	IsModuleImport bool
+}
+
+// IdxImportedASTFileInfo describes an imported AST file (PCH or module).
+type IdxImportedASTFileInfo struct {
+	// File is the top level AST file containing the imported PCH, module or
+	// submodule.
+	File File
+
+	// Module is the imported module or a null module if the AST file is a PCH.
+	Module Module
+
+	// Loc is the location where the file is imported. Applicable only for
+	// modules.
+	Loc IdxLoc
+
+	// IsImplicit is true if an inclusion directive was automatically turned
+	// into a module import. Applicable only for modules.
+	IsImplicit bool
+}
+
+// Indexer receives the callbacks of an indexing session driven by an
+// IndexAction.
+//
+// The values handed to an Indexer (cursors, locations, diagnostics) are only
+// valid while the translation unit being indexed is alive.
+// Diagnostics are only valid for the duration of the OnDiagnostic call.
+type Indexer interface {
+	// AbortQuery is called periodically to check whether indexing should
+	// be aborted.
+	AbortQuery() bool
+
+	// OnDiagnostic is called at the end of indexing with the complete
+	// diagnostic set.
+	OnDiagnostic(diags Diagnostics)
+
+	// OnEnteredMainFile is called when indexing enters the main file.
+	OnEnteredMainFile(f File)
+
+	// OnIncludedFile is called when a file gets #included/#imported.
+	OnIncludedFile(info IdxIncludedFileInfo)
+
+	// OnImportedASTFile is called when an AST file (PCH or module) gets
+	// imported.
+	//
+	// AST files will not get indexed (there will not be callbacks to index
+	// all the entities in an AST file). The recommended action is that, if
+	// the AST file is not already indexed, to initiate a new indexing job
+	// specific to the AST file.
+	OnImportedASTFile(info IdxImportedASTFileInfo)
+
+	// OnStartedTranslationUnit is called at the beginning of indexing a
+	// translation unit.
+	OnStartedTranslationUnit()
+
+	// OnDeclaration is called to index a declaration.
+	OnDeclaration(info IdxDeclInfo)
+
+	// OnEntityReference is called to index a reference of an entity.
+	OnEntityReference(info IdxEntityRefInfo)
+}
+
+// NopIndexer implements Indexer with no-op methods.
+// It is meant to be embedded in types only interested in a few callbacks.
+type NopIndexer struct{}
+
+func (NopIndexer) AbortQuery() bool                              { return false }
+func (NopIndexer) OnDiagnostic(diags Diagnostics)                {}
+func (NopIndexer) OnEnteredMainFile(f File)                      {}
+func (NopIndexer) OnIncludedFile(info IdxIncludedFileInfo)       {}
+func (NopIndexer) OnImportedASTFile(info IdxImportedASTFileInfo) {}
+func (NopIndexer) OnStartedTranslationUnit()                     {}
+func (NopIndexer) OnDeclaration(info IdxDeclInfo)                {}
+func (NopIndexer) OnEntityReference(info IdxEntityRefInfo)       {}
+
+/**
+ * \brief An indexing action/session, to be applied to one or multiple
+ * translation units.
+ */
+type IndexAction struct {
+	c C.CXIndexAction
+}
+
+/**
+ * \brief An indexing action/session, to be applied to one or multiple
+ * translation units.
+ *
+ * \param CIdx The index object with which the index action will be associated.
+ */
+func NewIndexAction(idx Index) IndexAction {
+	return IndexAction{C.clang_IndexAction_create(idx.c)}
+}
+
+/**
+ * \brief Destroy the given index action.
+ *
+ * The index action must not be destroyed until all of the translation units
+ * created within that index action have been destroyed.
+ */
+func (ia IndexAction) Dispose() {
+	C.clang_IndexAction_dispose(ia.c)
+}
+
+/**
+ * \brief Index the given source file and the translation unit corresponding
+ * to that file via callbacks implemented through the Indexer interface.
+ *
+ * \param index_options A bitmask of options that affects how indexing is
+ * performed. This should be a bitwise OR of the CXIndexOpt_XXX flags.
+ *
+ * \returns the translation unit, which can be reused after indexing is
+ * finished and must eventually be disposed. A nil error is returned on
+ * success or if there were errors from which the compiler could recover.
+ * If there is a failure from which there is no recovery, returns a non-nil
+ * ErrorCode.
+ *
+ * The rest of the parameters are the same as Index.Parse.
+ */
+func (ia IndexAction) IndexSourceFile(indexer Indexer, options IndexOptFlags, fname string, args []string, us UnsavedFiles, tuOptions TranslationUnitFlags) (TranslationUnit, error) {
+	var (
+		c_fname *C.char = nil
+		c_us            = us.to_c()
+		tu      TranslationUnit
+	)
+	defer c_us.Dispose()
+	if fname != "" {
+		c_fname = C.CString(fname)
+	}
+	defer C.free(unsafe.Pointer(c_fname))
+
+	c_nargs := C.int(len(args))
+	c_cmds := make([]*C.char, len(args))
+	for i, _ := range args {
+		cstr := C.CString(args[i])
+		defer C.free(unsafe.Pointer(cstr))
+		c_cmds[i] = cstr
+	}
+
+	var c_args **C.char = nil
+	if len(args) > 0 {
+		c_args = &c_cmds[0]
+	}
+
+	id := indexerCallbacks.add(indexer)
+	defer indexerCallbacks.remove(id)
+
+	o := C._go_clang_index_source_file(
+		ia.c,
+		C.uintptr_t(id),
+		C.uint(options),
+		c_fname,
+		c_args, c_nargs,
+		c_us.ptr(), C.uint(len(c_us)),
+		&tu.c,
+		C.uint(tuOptions))
+	return tu, newErrorCode(o)
+}
+
+/**
+ * \brief Index the given translation unit via callbacks implemented through
+ * the Indexer interface.
+ *
+ * The order of callback invocations is not guaranteed to be the same as
+ * when indexing a source file. The high level order will be:
+ *
+ *   -Preprocessor callbacks invocations
+ *   -Declaration/reference callbacks invocations
+ *   -Diagnostic callback invocations
+ *
+ * \returns If there is a failure from which the there is no recovery, returns
+ * a non-nil ErrorCode.
+ */
+func (ia IndexAction) IndexTranslationUnit(indexer Indexer, options IndexOptFlags, tu TranslationUnit) error {
+	id := indexerCallbacks.add(indexer)
+	defer indexerCallbacks.remove(id)
+
+	o := C._go_clang_index_translation_unit(ia.c, C.uintptr_t(id), C.uint(options), tu.c)
+	return newErrorCode(o)
+}
+
+var indexerCallbacks callbackRegistry[Indexer]
+
+//export GoClangIndexerAbortQuery
+func GoClangIndexerAbortQuery(client_data, reserved unsafe.Pointer) C.int {
+	if indexerCallbacks.get(uintptr(client_data)).AbortQuery() {
+		return 1
+	}
+	return 0
+}
+
+//export GoClangIndexerDiagnostic
+func GoClangIndexerDiagnostic(client_data unsafe.Pointer, set C.CXDiagnosticSet, reserved unsafe.Pointer) {
+	diags := DiagnosticSet{set}.Diagnostics()
+	indexerCallbacks.get(uintptr(client_data)).OnDiagnostic(diags)
+}
+
+//export GoClangIndexerEnteredMainFile
+func GoClangIndexerEnteredMainFile(client_data unsafe.Pointer, file C.CXFile, reserved unsafe.Pointer) C.CXIdxClientFile {
+	indexerCallbacks.get(uintptr(client_data)).OnEnteredMainFile(File{file})
+	return nil
+}
+
+//export GoClangIndexerPPIncludedFile
+func GoClangIndexerPPIncludedFile(client_data unsafe.Pointer, info *C.CXIdxIncludedFileInfo) C.CXIdxClientFile {
+	indexerCallbacks.get(uintptr(client_data)).OnIncludedFile(IdxIncludedFileInfo{
+		HashLoc:        IdxLoc{info.hashLoc},
+		Filename:       C.GoString(info.filename),
+		File:           File{info.file},
+		IsImport:       info.isImport != 0,
+		IsAngled:       info.isAngled != 0,
+		IsModuleImport: info.isModuleImport != 0,
+	})
+	return nil
+}
+
+//export GoClangIndexerImportedASTFile
+func GoClangIndexerImportedASTFile(client_data unsafe.Pointer, info *C.CXIdxImportedASTFileInfo) C.CXIdxClientASTFile {
+	indexerCallbacks.get(uintptr(client_data)).OnImportedASTFile(IdxImportedASTFileInfo{
+		File:       File{info.file},
+		Module:     Module{info.module},
+		Loc:        IdxLoc{info.loc},
+		IsImplicit: info.isImplicit != 0,
+	})
+	return nil
+}
+
+//export GoClangIndexerStartedTranslationUnit
+func GoClangIndexerStartedTranslationUnit(client_data, reserved unsafe.Pointer) C.CXIdxClientContainer {
+	indexerCallbacks.get(uintptr(client_data)).OnStartedTranslationUnit()
+	return nil
+}
+
+//export GoClangIndexerIndexDeclaration
+func GoClangIndexerIndexDeclaration(client_data unsafe.Pointer, info *C.CXIdxDeclInfo) {
+	indexerCallbacks.get(uintptr(client_data)).OnDeclaration(IdxDeclInfo{
+		EntityInfo:        newIdxEntityInfo(info.entityInfo),
+		Cursor:            Cursor{info.cursor},
+		Loc:               IdxLoc{info.loc},
+		SemanticContainer: newIdxContainerInfo(info.semanticContainer),
+		LexicalContainer:  newIdxContainerInfo(info.lexicalContainer),
+		IsRedeclaration:   info.isRedeclaration != 0,
+		IsDefinition:      info.isDefinition != 0,
+		IsContainer:       info.isContainer != 0,
+		DeclAsContainer:   newIdxContainerInfo(info.declAsContainer),
+		IsImplicit:        info.isImplicit != 0,
+		Flags:             IdxDeclInfoFlags(info.flags),
+	})
+}
+
+//export GoClangIndexerIndexEntityReference
+func GoClangIndexerIndexEntityReference(client_data unsafe.Pointer, info *C.CXIdxEntityRefInfo) {
+	indexerCallbacks.get(uintptr(client_data)).OnEntityReference(IdxEntityRefInfo{
+		Kind:             IdxEntityRefKind(info.kind),
+		Cursor:           Cursor{info.cursor},
+		Loc:              IdxLoc{info.loc},
+		ReferencedEntity: newIdxEntityInfo(info.referencedEntity),
+		ParentEntity:     newIdxEntityInfo(info.parentEntity),
+		Container:        newIdxContainerInfo(info.container),
+	})
+}
@@ -0,0 +1,117 @@
+package clang_test
+
+import (
+	"testing"
+
+	"github.com/sbinet/go-clang"
+)
+
+type testIndexer struct {
+	clang.NopIndexer
+	decls    map[string]int
+	refs     map[string]int
+	includes []string
+	started  bool
+}
+
+func (idx *testIndexer) OnStartedTranslationUnit() {
+	idx.started = true
+}
+
+func (idx *testIndexer) OnIncludedFile(info clang.IdxIncludedFileInfo) {
+	idx.includes = append(idx.includes, info.Filename)
+}
+
+func (idx *testIndexer) OnDeclaration(info clang.IdxDeclInfo) {
+	idx.decls[info.EntityInfo.Name]++
+}
+
+func (idx *testIndexer) OnEntityReference(info clang.IdxEntityRefInfo) {
+	idx.refs[info.ReferencedEntity.Name]++
+}
+
+func TestIndexSourceFile(t *testing.T) {
+	us := clang.UnsavedFiles{"index.c": `
+#include "struct.c"
+int sum(struct Foo f) {
+	return add(f.a, 1);
+}
+`}
+
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	action := clang.NewIndexAction(idx)
+	defer action.Dispose()
+
+	indexer := &testIndexer{
+		decls: make(map[string]int),
+		refs:  make(map[string]int),
+	}
+	tu, err := action.IndexSourceFile(indexer, clang.IndexOpt_None, "index.c", []string{"-Itestdata"}, us, 0)
+	if err != nil {
+		t.Fatalf("error indexing source file: %v", err)
+	}
+	defer tu.Dispose()
+
+	if !indexer.started {
+		t.Errorf("expected OnStartedTranslationUnit to be called")
+	}
+	if len(indexer.includes) != 1 || indexer.includes[0] != "struct.c" {
+		t.Errorf("expected includes=[struct.c]. got=%v", indexer.includes)
+	}
+	for _, name := range []string{"Foo", "a", "b", "add", "sum", "f"} {
+		if indexer.decls[name] == 0 {
+			t.Errorf("expected a declaration for %q", name)
+		}
+	}
+	if n := indexer.decls["add"]; n != 2 {
+		t.Errorf("expected 2 declarations of 'add'. got=%d", n)
+	}
+	for _, name := range []string{"Foo", "add", "a"} {
+		if indexer.refs[name] == 0 {
+			t.Errorf("expected a reference to %q", name)
+		}
+	}
+}
+
+type abortIndexer struct {
+	clang.NopIndexer
+	abort bool
+	n     int
+}
+
+func (idx *abortIndexer) AbortQuery() bool {
+	return idx.abort && idx.n > 0
+}
+
+func (idx *abortIndexer) OnDeclaration(info clang.IdxDeclInfo) {
+	idx.n++
+}
+
+func TestIndexTranslationUnitAbort(t *testing.T) {
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := idx.Parse("testdata/struct.c", nil, nil, 0)
+	if !tu.IsValid() {
+		t.Fatal("TranslationUnit is not valid")
+	}
+	defer tu.Dispose()
+
+	action := clang.NewIndexAction(idx)
+	defer action.Dispose()
+
+	all := &abortIndexer{}
+	err := action.IndexTranslationUnit(all, clang.IndexOpt_None, tu)
+	if err != nil {
+		t.Fatalf("error indexing translation unit: %v", err)
+	}
+
+	some := &abortIndexer{abort: true}
+	err = action.IndexTranslationUnit(some, clang.IndexOpt_None, tu)
+	if err != nil {
+		t.Fatalf("error indexing translation unit: %v", err)
+	}
+	if some.n == 0 || some.n >= all.n {
+		t.Errorf("expected indexing to abort early. got=%d declarations (want < %d)", some.n, all.n)
+	}
+}
@@ -0,0 +1,52 @@
+/* helper functions to drive the libclang indexer
+ */
+
+#include "_cgo_export.h"
+#include "go-clang.h"
+
+static IndexerCallbacks _go_clang_indexer_callbacks = {
+  (int (*)(CXClientData, void *))&GoClangIndexerAbortQuery,
+  (void (*)(CXClientData, CXDiagnosticSet, void *))&GoClangIndexerDiagnostic,
+  (CXIdxClientFile (*)(CXClientData, CXFile, void *))&GoClangIndexerEnteredMainFile,
+  (CXIdxClientFile (*)(CXClientData, const CXIdxIncludedFileInfo *))&GoClangIndexerPPIncludedFile,
+  (CXIdxClientASTFile (*)(CXClientData, const CXIdxImportedASTFileInfo *))&GoClangIndexerImportedASTFile,
+  (CXIdxClientContainer (*)(CXClientData, void *))&GoClangIndexerStartedTranslationUnit,
+  (void (*)(CXClientData, const CXIdxDeclInfo *))&GoClangIndexerIndexDeclaration,
+  (void (*)(CXClientData, const CXIdxEntityRefInfo *))&GoClangIndexerIndexEntityReference,
+};
+
+int
+_go_clang_index_source_file(CXIndexAction action,
+                            uintptr_t callback_id,
+                            unsigned index_options,
+                            const char *source_filename,
+                            const char * const *command_line_args,
+                            int num_command_line_args,
+                            struct CXUnsavedFile *unsaved_files,
+                            unsigned num_unsaved_files,
+                            CXTranslationUnit *out_TU,
+                            unsigned TU_options)
+{
+  return clang_indexSourceFile(action, (CXClientData)callback_id,
+                               &_go_clang_indexer_callbacks,
+                               sizeof(_go_clang_indexer_callbacks),
+                               index_options,
+                               source_filename,
+                               command_line_args, num_command_line_args,
+                               unsaved_files, num_unsaved_files,
+                               out_TU, TU_options);
+}
+
+int
+_go_clang_index_translation_unit(CXIndexAction action,
+                                 uintptr_t callback_id,
+                                 unsigned index_options,
+                                 CXTranslationUnit tu)
+{
+  return clang_indexTranslationUnit(action, (CXClientData)callback_id,
+                                    &_go_clang_indexer_callbacks,
+                                    sizeof(_go_clang_indexer_callbacks),
+                                    index_options, tu);
+}
+
+/* EOF */
@@ -0,0 +1,234 @@
+// Package isolate parses translation units in worker subprocesses, so a
+// crash of libclang does not take down the calling process.
+//
+// The worker binary (see go-clang-worker) runs Index.Parse, visits the
+// resulting translation unit and streams back a Snapshot of its AST and
+// diagnostics over its standard output. The AST is the same Go-native tree
+// as the one created by package ast/astclang.
+//
+// ex:
+//
+//	p := isolate.Parser{}
+//	snap, err := p.Parse(ctx, "foo.c", args, nil, 0)
+//	if errors.Is(err, clang.Error_Crashed) {
+//		// libclang crashed while parsing foo.c
+//	}
+package isolate
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/sbinet/go-clang"
+	"github.com/sbinet/go-clang/ast"
+	"github.com/sbinet/go-clang/ast/astclang"
+)
+
+// DefaultWorker is the name of the worker binary used by a Parser when
+// Parser.Worker is empty. It is looked up in the directories named by the
+// PATH environment variable.
+const DefaultWorker = "go-clang-worker"
+
+// Request describes a translation unit to parse.
+type Request struct {
+	Filename     string
+	Args         []string
+	UnsavedFiles clang.UnsavedFiles
+	Options      clang.TranslationUnitFlags
+}
+
+// Response is sent back by a worker once a translation unit was parsed.
+type Response struct {
+	Snapshot *Snapshot
+	Err      string // non-empty if the translation unit could not be parsed
+	Code     clang.ErrorCode
+}
+
+// Snapshot holds the AST and diagnostics of a translation unit.
+type Snapshot struct {
+	Root        *ast.Node // translation unit cursor, see package ast
+	Diagnostics []Diagnostic
+}
+
+// Diagnostic is a diagnostic reported while parsing a translation unit.
+type Diagnostic struct {
+	Severity clang.DiagnosticSeverity
+	File     string
+	Line     uint
+	Column   uint
+	Message  string
+	Option   string
+}
+
+// ErrCrashed is returned by Parser.Parse when the worker subprocess was
+// killed by a signal before sending back its response, e.g. after a crash
+// of libclang.
+// ErrCrashed matches clang.Error_Crashed with errors.Is.
+type ErrCrashed struct {
+	State  *os.ProcessState // nil if the worker could not be waited for
+	Stderr string           // standard error of the worker
+}
+
+func (err *ErrCrashed) Error() string {
+	return workerError("crashed", err.State, err.Stderr)
+}
+
+func (err *ErrCrashed) Is(target error) bool {
+	return target == clang.Error_Crashed
+}
+
+// ErrFailed is returned by Parser.Parse when the worker subprocess exited
+// without sending back a valid response, e.g. after a protocol error.
+type ErrFailed struct {
+	State  *os.ProcessState // state of the exited worker
+	Stderr string           // standard error of the worker
+}
+
+func (err *ErrFailed) Error() string {
+	return workerError("failed", err.State, err.Stderr)
+}
+
+func workerError(what string, state *os.ProcessState, stderr string) string {
+	msg := "go-clang: worker " + what
+	if state != nil {
+		msg += " (" + state.String() + ")"
+	}
+	if stderr := strings.TrimSpace(stderr); stderr != "" {
+		msg += ": " + stderr
+	}
+	return msg
+}
+
+// Parser parses translation units in worker subprocesses.
+// A new worker is started for each translation unit.
+type Parser struct {
+	// Worker is the path to the worker binary.
+	// DefaultWorker is used if Worker is empty.
+	Worker string
+
+	// Args are additional command-line arguments for the worker.
+	Args []string
+}
+
+// Parse parses the given source file in a worker subprocess, and returns
+// the snapshot of the resulting translation unit.
+// Parse returns an *ErrCrashed error if the worker was killed by a signal,
+// an *ErrFailed error if it exited without a valid response, and ctx.Err() if
+// ctx was cancelled before the worker completed, in which case the worker
+// is killed.
+func (p *Parser) Parse(ctx context.Context, fname string, args []string, us clang.UnsavedFiles, options clang.TranslationUnitFlags) (*Snapshot, error) {
+	worker := p.Worker
+	if worker == "" {
+		worker = DefaultWorker
+	}
+
+	var (
+		stdin  = new(bytes.Buffer)
+		stdout = new(bytes.Buffer)
+		stderr = new(bytes.Buffer)
+	)
+	err := gob.NewEncoder(stdin).Encode(Request{
+		Filename:     fname,
+		Args:         args,
+		UnsavedFiles: us,
+		Options:      options,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("go-clang: could not encode request: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, worker, p.Args...)
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err = cmd.Run()
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+
+	var resp Response
+	if derr := gob.NewDecoder(stdout).Decode(&resp); derr != nil {
+		var eerr *exec.ExitError
+		switch {
+		case errors.As(err, &eerr) && eerr.ExitCode() == -1:
+			// killed by a signal.
+			return nil, &ErrCrashed{State: cmd.ProcessState, Stderr: stderr.String()}
+		case err == nil || errors.As(err, &eerr):
+			return nil, &ErrFailed{State: cmd.ProcessState, Stderr: stderr.String()}
+		default:
+			// the worker could not be started.
+			return nil, err
+		}
+	}
+
+	if resp.Err != "" {
+		if resp.Code != clang.Error_Success {
+			return nil, fmt.Errorf("go-clang: worker: %s: %w", resp.Err, resp.Code)
+		}
+		return nil, fmt.Errorf("go-clang: worker: %s", resp.Err)
+	}
+	return resp.Snapshot, nil
+}
+
+// Serve reads a Request from r, parses the requested translation unit and
+// writes the Response to w.
+// Serve is meant to be run by the worker binary, with r and w connected to
+// its standard input and output.
+func Serve(r io.Reader, w io.Writer) error {
+	var req Request
+	if err := gob.NewDecoder(r).Decode(&req); err != nil {
+		return fmt.Errorf("go-clang: could not decode request: %w", err)
+	}
+
+	resp := serve(req)
+	if err := gob.NewEncoder(w).Encode(resp); err != nil {
+		return fmt.Errorf("go-clang: could not encode response: %w", err)
+	}
+	return nil
+}
+
+func serve(req Request) Response {
+	// let a crash kill the worker: the parent process reports it.
+	clang.ToggleCrashRecovery(false)
+
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+
+	tu, err := idx.ParseErr(req.Filename, req.Args, req.UnsavedFiles, req.Options)
+	if err != nil {
+		resp := Response{Err: err.Error()}
+		errors.As(err, &resp.Code)
+		return resp
+	}
+	defer tu.Dispose()
+
+	return Response{Snapshot: newSnapshot(tu)}
+}
+
+func newSnapshot(tu clang.TranslationUnit) *Snapshot {
+	snap := &Snapshot{Root: astclang.NewNode(tu.ToCursor(), astclang.Options{})}
+
+	diags := tu.Diagnostics()
+	defer diags.Dispose()
+	for _, d := range diags {
+		f, line, col, _ := d.Location().SpellingLocation()
+		opt, _ := d.Option()
+		snap.Diagnostics = append(snap.Diagnostics, Diagnostic{
+			Severity: d.Severity(),
+			File:     f.Name(),
+			Line:     line,
+			Column:   col,
+			Message:  d.Spelling(),
+			Option:   opt,
+		})
+	}
+	return snap
+}
@@ -0,0 +1,138 @@
+package isolate_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sbinet/go-clang"
+	"github.com/sbinet/go-clang/isolate"
+)
+
+// worker is the path to the go-clang-worker binary built by TestMain.
+var worker string
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	tmp, err := os.MkdirTemp("", "go-clang-isolate-")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "could not create tmp dir: %v\n", err)
+		return 1
+	}
+	defer os.RemoveAll(tmp)
+
+	exe := filepath.Join(tmp, "go-clang-worker")
+	cmd := exec.Command("go", "build", "-o", exe, "../go-clang-worker")
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "could not build go-clang-worker: %v\n", err)
+		return 1
+	}
+	worker = exe
+
+	return m.Run()
+}
+
+func TestParse(t *testing.T) {
+	us := clang.UnsavedFiles{"worker.c": `
+struct Point { int x, y; };
+int norm(struct Point p) { return p.x * p.x + p.y * p.y }
+`}
+
+	p := isolate.Parser{Worker: worker}
+	snap, err := p.Parse(context.Background(), "worker.c", nil, us, 0)
+	if err != nil {
+		t.Fatalf("error parsing in worker: %v", err)
+	}
+
+	if snap.Root.Kind != "TranslationUnit" {
+		t.Errorf("expected a translation unit root. got=%v", snap.Root.Kind)
+	}
+	names := make(map[string]string)
+	for _, n := range snap.Root.Children {
+		names[n.Spelling] = n.Kind
+	}
+	if names["Point"] != "StructDecl" {
+		t.Errorf("expected a struct 'Point'. got=%v", names)
+	}
+	if names["norm"] != "FunctionDecl" {
+		t.Errorf("expected a function 'norm'. got=%v", names)
+	}
+
+	if len(snap.Diagnostics) != 1 {
+		t.Fatalf("expected 1 diagnostic. got=%d", len(snap.Diagnostics))
+	}
+	if d := snap.Diagnostics[0]; d.Severity != clang.Diagnostic_Error || d.Line != 3 {
+		t.Errorf("expected an error on line 3. got=%+v", d)
+	}
+}
+
+func TestParseCrashed(t *testing.T) {
+	// a libclang crash, with crash recovery disabled by the worker.
+	us := clang.UnsavedFiles{"crash.c": "#pragma clang __debug crash\n"}
+
+	p := isolate.Parser{Worker: worker}
+	_, err := p.Parse(context.Background(), "crash.c", nil, us, 0)
+
+	var crashed *isolate.ErrCrashed
+	if !errors.As(err, &crashed) {
+		t.Fatalf("expected an ErrCrashed error. got=%v", err)
+	}
+	if !errors.Is(err, clang.Error_Crashed) {
+		t.Errorf("expected error to match clang.Error_Crashed")
+	}
+}
+
+func TestParseKilled(t *testing.T) {
+	// a worker killed by a signal without sending a response.
+	p := isolate.Parser{Worker: "sh", Args: []string{"-c", "kill -SEGV $$"}}
+	_, err := p.Parse(context.Background(), "worker.c", nil, nil, 0)
+
+	var crashed *isolate.ErrCrashed
+	if !errors.As(err, &crashed) {
+		t.Fatalf("expected an ErrCrashed error. got=%v", err)
+	}
+	if !errors.Is(err, clang.Error_Crashed) {
+		t.Errorf("expected error to match clang.Error_Crashed")
+	}
+}
+
+func TestParseFailed(t *testing.T) {
+	// a worker exiting without sending a response.
+	p := isolate.Parser{Worker: "sh", Args: []string{"-c", "echo 'bad request' >&2; exit 1"}}
+	_, err := p.Parse(context.Background(), "worker.c", nil, nil, 0)
+
+	var failed *isolate.ErrFailed
+	if !errors.As(err, &failed) {
+		t.Fatalf("expected an ErrFailed error. got=%v", err)
+	}
+	if errors.Is(err, clang.Error_Crashed) {
+		t.Errorf("expected error not to match clang.Error_Crashed")
+	}
+	if got := strings.TrimSpace(failed.Stderr); got != "bad request" {
+		t.Errorf("expected stderr %q. got=%q", "bad request", got)
+	}
+	if got := failed.State.ExitCode(); got != 1 {
+		t.Errorf("expected exit code 1. got=%d", got)
+	}
+}
+
+func TestParseCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p := isolate.Parser{Worker: worker}
+	_, err := p.Parse(ctx, "worker.c", nil, nil, 0)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled. got=%v", err)
+	}
+}
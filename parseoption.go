@@ -0,0 +1,109 @@
+package clang
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+)
+
+// ParseOption configures how a translation unit is parsed by Index.Parse.
+type ParseOption func(cfg *parseConfig) error
+
+type parseConfig struct {
+	args []string
+	us   UnsavedFiles
+}
+
+func newParseConfig(args []string, us UnsavedFiles, opts []ParseOption) (*parseConfig, error) {
+	cfg := &parseConfig{args: args, us: us}
+	if len(opts) == 0 {
+		return cfg, nil
+	}
+
+	// do not modify the caller's arguments nor unsaved files.
+	cfg.args = append([]string(nil), args...)
+	cfg.us = us.clone()
+	if cfg.us == nil {
+		cfg.us = make(UnsavedFiles)
+	}
+
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// WithFS mounts the files of fsys under the absolute directory root and
+// adds root to the include search path, so #include directives are
+// resolved against the content of fsys.
+// Nothing is written to disk: the files are handed to libclang as
+// unsaved files. Unsaved files given explicitly to Index.Parse take
+// precedence over the ones from fsys.
+//
+// The mounted files are read in full, on every parse, whether they are
+// included or not. If patterns are given, only the files whose base name
+// matches one of them (see path.Match) are mounted, e.g. "*.h" and
+// "*.hpp" to skip everything but headers from a large embed.FS.
+//
+// ex:
+//
+//	//go:embed include
+//	var headers embed.FS
+//
+//	inc, _ := fs.Sub(headers, "include")
+//	tu := idx.Parse("main.c", nil, nil, 0, clang.WithFS("/virtual/include", inc, "*.h"))
+func WithFS(root string, fsys fs.FS, patterns ...string) ParseOption {
+	return func(cfg *parseConfig) error {
+		if !filepath.IsAbs(root) {
+			return fmt.Errorf("go-clang: virtual root %q is not an absolute path", root)
+		}
+		root = filepath.Clean(root)
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("go-clang: invalid pattern %q: %w", pattern, err)
+			}
+		}
+
+		err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.Type().IsRegular() || !matchAny(patterns, path.Base(name)) {
+				return nil
+			}
+			fname := filepath.Join(root, filepath.FromSlash(name))
+			if _, dup := cfg.us[fname]; dup {
+				return nil
+			}
+			data, err := fs.ReadFile(fsys, name)
+			if err != nil {
+				return err
+			}
+			cfg.us[fname] = string(data)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("go-clang: could not mount file system at %q: %w", root, err)
+		}
+
+		cfg.args = append(cfg.args, "-I"+root)
+		return nil
+	}
+}
+
+// matchAny returns whether name matches one of patterns.
+// An empty list of patterns matches every name.
+func matchAny(patterns []string, name string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
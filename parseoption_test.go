@@ -0,0 +1,86 @@
+package clang_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/sbinet/go-clang"
+)
+
+func TestParseWithFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"vendor/foo.h": {Data: []byte("int foo(void);\n")},
+		"bar.h": {Data: []byte(`
+#include <vendor/foo.h>
+static int bar(void) { return foo(); }
+`)},
+	}
+	us := clang.UnsavedFiles{"main.c": `
+#include <bar.h>
+int main(void) { return bar(); }
+`}
+
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := idx.Parse("main.c", nil, us, 0, clang.WithFS("/virtual/include", fsys))
+	if !tu.IsValid() {
+		t.Fatal("TranslationUnit is not valid")
+	}
+	defer tu.Dispose()
+
+	diags := tu.Diagnostics()
+	defer diags.Dispose()
+	for _, d := range diags {
+		if d.Severity() >= clang.Diagnostic_Warning {
+			t.Errorf("unexpected diagnostic: %v", d)
+		}
+	}
+
+	names := make(map[string]bool)
+	for _, inc := range tu.Inclusions() {
+		names[inc.File.Name()] = true
+	}
+	for _, name := range []string{"/virtual/include/bar.h", "/virtual/include/vendor/foo.h"} {
+		if !names[name] {
+			t.Errorf("expected %q to be included. got=%v", name, names)
+		}
+	}
+}
+
+func TestParseWithFSRelativeRoot(t *testing.T) {
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := idx.Parse("main.c", nil, clang.UnsavedFiles{"main.c": ""}, 0, clang.WithFS("include", fstest.MapFS{}))
+	if tu.IsValid() {
+		tu.Dispose()
+		t.Fatal("expected an invalid TranslationUnit")
+	}
+}
+
+func TestParseWithFSPatterns(t *testing.T) {
+	fsys := fstest.MapFS{
+		"foo.h":   {Data: []byte("int foo(void);\n")},
+		"foo.inc": {Data: []byte("int bar(void);\n")},
+	}
+	us := clang.UnsavedFiles{"main.c": `
+#include <foo.h>
+#include <foo.inc>
+`}
+
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := idx.Parse("main.c", nil, us, 0, clang.WithFS("/virtual/include", fsys, "*.h"))
+	if !tu.IsValid() {
+		t.Fatal("TranslationUnit is not valid")
+	}
+	defer tu.Dispose()
+
+	diags := tu.Diagnostics()
+	defer diags.Dispose()
+	if len(diags) != 1 || diags[0].Severity() < clang.Diagnostic_Error {
+		t.Fatalf("expected 1 error about foo.inc. got=%d diagnostics", len(diags))
+	}
+	if f, line, _, _ := diags[0].Location().SpellingLocation(); f.Name() != "main.c" || line != 3 {
+		t.Errorf("expected the error on main.c:3. got=%s:%d", f.Name(), line)
+	}
+}
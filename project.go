@@ -0,0 +1,142 @@
+package clang
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProjectFunc is called by Project.Parse for each compile command of the
+// compilation database.
+// tu is only valid during the call: it is disposed when ProjectFunc returns.
+// err is non-nil if the translation unit could not be created.
+type ProjectFunc func(cmd CompileCommand, tu TranslationUnit, err error)
+
+// Project parses all the entries of a compilation database in parallel.
+type Project struct {
+	db      *CompilationDatabase
+	workers int
+
+	// Timeout is the maximum duration allowed to parse a single file.
+	// A zero Timeout means no timeout.
+	// As libclang can not interrupt a parse, a timed out parse keeps running
+	// in the background and its result is discarded (see
+	// Index.ParseContext). The worker carries on with a fresh Index, and the
+	// Index of the timed out parse is disposed of once the parse completes.
+	// Parse waits for it before returning.
+	Timeout time.Duration
+
+	// Options are the flags used to parse each translation unit.
+	Options TranslationUnitFlags
+}
+
+// NewProject creates a project parsing the compile commands of db with the
+// given number of parallel workers.
+// If workers is less than 1, runtime.NumCPU workers are used.
+func NewProject(db *CompilationDatabase, workers int) *Project {
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	return &Project{db: db, workers: workers}
+}
+
+// newProjectIndex creates the Index of a Project worker.
+// It is replaced in tests.
+var newProjectIndex = func() Index {
+	return NewIndex(0, 0)
+}
+
+type projectJob struct {
+	cmd  CompileCommand
+	args []string
+}
+
+// Parse parses every compile command of the compilation database and
+// streams the results to fn.
+// Identical compile commands (same working directory and arguments) are
+// only parsed once.
+// Each worker uses its own Index. Calls to fn are serialized.
+func (p *Project) Parse(fn ProjectFunc) {
+	cmds := p.db.GetAllCompileCommands()
+	defer cmds.Dispose()
+
+	var (
+		jobs = make(chan projectJob)
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+	)
+
+	for i := 0; i < p.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			idx := newProjectIndex()
+			defer func() {
+				// idx may have been replaced after a timeout.
+				idx.Dispose()
+			}()
+
+			for job := range jobs {
+				tu, err := p.parse(idx, job.args)
+				if errors.Is(err, context.DeadlineExceeded) {
+					// idx is still used by the abandoned parse: it must not
+					// be shared with the next job. Index.Dispose waits for
+					// the abandoned parse to complete.
+					wg.Add(1)
+					go func(idx Index) {
+						defer wg.Done()
+						idx.Dispose()
+					}(idx)
+					idx = newProjectIndex()
+				}
+				mu.Lock()
+				fn(job.cmd, tu, err)
+				mu.Unlock()
+				if tu.IsValid() {
+					tu.Dispose()
+				}
+			}
+		}()
+	}
+
+	seen := make(map[string]bool)
+	for i, n := 0, cmds.GetSize(); i < n; i++ {
+		cmd := cmds.GetCommand(i)
+		args := cmd.parseArgs()
+		key := strings.Join(args, "\x00")
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		jobs <- projectJob{cmd: cmd, args: args}
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// parseArgs returns the arguments to hand to Index.Parse to parse the
+// compile command: the compiler executable is dropped, and the working
+// directory of the command is set.
+func (cmd CompileCommand) parseArgs() []string {
+	n := cmd.GetNumArgs()
+	args := make([]string, 0, n+1)
+	for i := 1; i < n; i++ {
+		args = append(args, cmd.GetArg(i))
+	}
+	if dir := cmd.GetDirectory(); dir != "" {
+		args = append(args, "-working-directory="+dir)
+	}
+	return args
+}
+
+func (p *Project) parse(idx Index, args []string) (TranslationUnit, error) {
+	if p.Timeout <= 0 {
+		return idx.ParseErr("", args, nil, p.Options)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), p.Timeout)
+	defer cancel()
+	return idx.ParseContext(ctx, "", args, nil, p.Options)
+}
@@ -0,0 +1,76 @@
+package clang_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/sbinet/go-clang"
+)
+
+func TestProject(t *testing.T) {
+	dir := t.TempDir()
+	for name, src := range map[string]string{
+		"a.c": "int a(void) { return 1; }\n",
+		"b.c": "int b(void) { return 2; }\n",
+		"c.c": "int c(void) { return }\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type entry struct {
+		Directory string `json:"directory"`
+		Command   string `json:"command"`
+		File      string `json:"file"`
+	}
+	db := []entry{
+		{dir, "cc -c a.c", "a.c"},
+		{dir, "cc -c b.c", "b.c"},
+		{dir, "cc -c a.c", "a.c"}, // duplicate
+		{dir, "cc -c c.c", "c.c"},
+	}
+	buf, err := json.Marshal(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "compile_commands.json"), buf, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cdb, err := clang.NewCompilationDatabase(dir)
+	if err != nil {
+		t.Fatalf("error loading compilation database: %v", err)
+	}
+	defer cdb.Dispose()
+
+	var (
+		files []string
+		nerrs int
+	)
+	clang.NewProject(&cdb, 2).Parse(func(cmd clang.CompileCommand, tu clang.TranslationUnit, err error) {
+		if err != nil {
+			t.Errorf("error parsing %v: %v", cmd.GetArg(cmd.GetNumArgs()-1), err)
+			return
+		}
+		files = append(files, filepath.Base(tu.Spelling()))
+		diags := tu.Diagnostics()
+		defer diags.Dispose()
+		for _, d := range diags {
+			if d.Severity() >= clang.Diagnostic_Error {
+				nerrs++
+			}
+		}
+	})
+
+	sort.Strings(files)
+	if len(files) != 3 || files[0] != "a.c" || files[1] != "b.c" || files[2] != "c.c" {
+		t.Errorf("expected [a.c b.c c.c]. got=%v", files)
+	}
+	if nerrs != 1 {
+		t.Errorf("expected 1 error diagnostic. got=%d", nerrs)
+	}
+}
@@ -0,0 +1,99 @@
+package clang
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestProjectTimeout(t *testing.T) {
+	// sources large enough for their parse to outlive the timeout.
+	var src strings.Builder
+	for i := 0; i < 20000; i++ {
+		fmt.Fprintf(&src, "struct s%[1]d { int a, b; }; int f%[1]d(struct s%[1]d v) { return v.a + v.b; }\n", i)
+	}
+
+	const nfiles = 3
+	dir := t.TempDir()
+	type entry struct {
+		Directory string `json:"directory"`
+		Command   string `json:"command"`
+		File      string `json:"file"`
+	}
+	var db []entry
+	for i := 0; i < nfiles; i++ {
+		name := fmt.Sprintf("f%d.c", i)
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src.String()), 0644); err != nil {
+			t.Fatal(err)
+		}
+		db = append(db, entry{dir, "cc -c " + name, name})
+	}
+	buf, err := json.Marshal(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "compile_commands.json"), buf, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cdb, err := NewCompilationDatabase(dir)
+	if err != nil {
+		t.Fatalf("error loading compilation database: %v", err)
+	}
+	defer cdb.Dispose()
+
+	var nindices atomic.Int32
+	defer func(f func() Index) { newProjectIndex = f }(newProjectIndex)
+	newProjectIndex = func() Index {
+		nindices.Add(1)
+		return NewIndex(0, 0)
+	}
+
+	ngoroutines := runtime.NumGoroutine()
+
+	p := NewProject(&cdb, 1)
+	p.Timeout = time.Millisecond
+	n := 0
+	p.Parse(func(cmd CompileCommand, tu TranslationUnit, err error) {
+		n++
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded. got=%v", err)
+		}
+		if tu.IsValid() {
+			t.Errorf("expected an invalid TranslationUnit")
+		}
+	})
+	if n != nfiles {
+		t.Errorf("expected %d calls. got=%d", nfiles, n)
+	}
+
+	// one index for the worker, and a fresh one after each timeout.
+	if got, want := nindices.Load(), int32(1+nfiles); got != want {
+		t.Errorf("expected %d indices. got=%d", want, got)
+	}
+
+	// all the abandoned parses completed, and their indices were disposed.
+	abandonedOps.lock.Lock()
+	nops := len(abandonedOps.ops)
+	abandonedOps.lock.Unlock()
+	if nops != 0 {
+		t.Errorf("expected no abandoned operations left. got=%d", nops)
+	}
+
+	// goroutines may take a moment to exit once they are done.
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > ngoroutines && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := runtime.NumGoroutine(); got > ngoroutines {
+		t.Errorf("leaked goroutines: got=%d, want=%d", got, ngoroutines)
+	}
+}
@@ -0,0 +1,102 @@
+package clang
+
+// #include <stdlib.h>
+// #include "go-clang.h"
+import "C"
+
+/**
+ * \brief Categorizes how memory is being used by a translation unit.
+ */
+type TUResourceUsageKind int
+
+const (
+	TUResourceUsage_AST                                TUResourceUsageKind = C.CXTUResourceUsage_AST
+	TUResourceUsage_Identifiers                                            = C.CXTUResourceUsage_Identifiers
+	TUResourceUsage_Selectors                                              = C.CXTUResourceUsage_Selectors
+	TUResourceUsage_GlobalCompletionResults                                = C.CXTUResourceUsage_GlobalCompletionResults
+	TUResourceUsage_SourceManagerContentCache                              = C.CXTUResourceUsage_SourceManagerContentCache
+	TUResourceUsage_AST_SideTables                                         = C.CXTUResourceUsage_AST_SideTables
+	TUResourceUsage_SourceManager_Membuffer_Malloc                         = C.CXTUResourceUsage_SourceManager_Membuffer_Malloc
+	TUResourceUsage_SourceManager_Membuffer_MMap                           = C.CXTUResourceUsage_SourceManager_Membuffer_MMap
+	TUResourceUsage_ExternalASTSource_Membuffer_Malloc                     = C.CXTUResourceUsage_ExternalASTSource_Membuffer_Malloc
+	TUResourceUsage_ExternalASTSource_Membuffer_MMap                       = C.CXTUResourceUsage_ExternalASTSource_Membuffer_MMap
+	TUResourceUsage_Preprocessor                                           = C.CXTUResourceUsage_Preprocessor
+	TUResourceUsage_PreprocessingRecord                                    = C.CXTUResourceUsage_PreprocessingRecord
+	TUResourceUsage_SourceManager_DataStructures                           = C.CXTUResourceUsage_SourceManager_DataStructures
+	TUResourceUsage_Preprocessor_HeaderSearch                              = C.CXTUResourceUsage_Preprocessor_HeaderSearch
+
+	TUResourceUsage_MEMORY_IN_BYTES_BEGIN TUResourceUsageKind = C.CXTUResourceUsage_MEMORY_IN_BYTES_BEGIN
+	TUResourceUsage_MEMORY_IN_BYTES_END                       = C.CXTUResourceUsage_MEMORY_IN_BYTES_END
+
+	TUResourceUsage_First TUResourceUsageKind = C.CXTUResourceUsage_First
+	TUResourceUsage_Last                      = C.CXTUResourceUsage_Last
+)
+
+/**
+ * \brief Returns the human-readable null-terminated C string that represents
+ *  the name of the memory category.  This string should never be freed.
+ */
+func (k TUResourceUsageKind) String() string {
+	s := C.clang_getTUResourceUsageName(C.enum_CXTUResourceUsageKind(k))
+	if s == nil {
+		return ""
+	}
+	return C.GoString(s)
+}
+
+// IsMemoryInBytes returns whether the amount of resources of this kind
+// is expressed in bytes of memory.
+func (k TUResourceUsageKind) IsMemoryInBytes() bool {
+	return TUResourceUsage_MEMORY_IN_BYTES_BEGIN <= k && k <= TUResourceUsage_MEMORY_IN_BYTES_END
+}
+
+// TUResourceUsageEntry is the amount of resources used by a translation
+// unit for a given category.
+type TUResourceUsageEntry struct {
+	Kind   TUResourceUsageKind
+	Amount uint64 // units depend on Kind
+}
+
+// TUResourceUsage is the memory usage of a translation unit, broken into
+// categories.
+type TUResourceUsage []TUResourceUsageEntry
+
+/**
+ * \brief Return the memory usage of a translation unit.
+ */
+func (tu TranslationUnit) ResourceUsage() TUResourceUsage {
+	c := C.clang_getCXTUResourceUsage(tu.c)
+	defer C.clang_disposeCXTUResourceUsage(c)
+
+	ret := make(TUResourceUsage, int(c.numEntries))
+	for i := range ret {
+		e := C._go_clang_tu_resource_usage_entry_at(c.entries, C.int(i))
+		ret[i] = TUResourceUsageEntry{
+			Kind:   TUResourceUsageKind(e.kind),
+			Amount: uint64(e.amount),
+		}
+	}
+	return ret
+}
+
+// Map returns the amount of resources used for each category, indexed by
+// the name of the category.
+func (u TUResourceUsage) Map() map[string]uint64 {
+	ret := make(map[string]uint64, len(u))
+	for _, e := range u {
+		ret[e.Kind.String()] += e.Amount
+	}
+	return ret
+}
+
+// MemoryInBytes returns the total amount of memory, in bytes, used by the
+// translation unit.
+func (u TUResourceUsage) MemoryInBytes() uint64 {
+	var n uint64
+	for _, e := range u {
+		if e.Kind.IsMemoryInBytes() {
+			n += e.Amount
+		}
+	}
+	return n
+}
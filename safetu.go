@@ -0,0 +1,199 @@
+package clang
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+
+	"github.com/sbinet/go-clang/ast"
+)
+
+var (
+	// ErrSafeTUClosed is returned when using a SafeTU after it was closed.
+	ErrSafeTUClosed = errors.New("go-clang: SafeTU is closed")
+
+	// ErrStaleSnapshot is returned when querying a TUSnapshot after the
+	// underlying translation unit was reparsed.
+	ErrStaleSnapshot = errors.New("go-clang: snapshot invalidated by reparse")
+)
+
+// SafeTU wraps a TranslationUnit so it can be shared by several goroutines.
+//
+// libclang translation units are not safe for concurrent use: SafeTU routes
+// all the calls to the translation unit through a single goroutine, locked
+// to its OS thread.
+// Values derived from the translation unit (cursors, types, source
+// locations, ...) must not escape the functions run by Do, as they are only
+// valid on the executor goroutine and until the next reparse.
+type SafeTU struct {
+	calls chan func()
+	quit  chan struct{}
+	done  chan struct{}
+	once  sync.Once
+
+	// only accessed from the executor goroutine.
+	tu  TranslationUnit
+	gen uint64
+}
+
+// NewSafeTU takes ownership of tu and returns a goroutine-safe wrapper
+// around it. The translation unit is disposed when the SafeTU is closed.
+func NewSafeTU(tu TranslationUnit) *SafeTU {
+	s := &SafeTU{
+		calls: make(chan func()),
+		quit:  make(chan struct{}),
+		done:  make(chan struct{}),
+		tu:    tu,
+	}
+	go s.run()
+	return s
+}
+
+func (s *SafeTU) run() {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	defer close(s.done)
+
+	for {
+		select {
+		case f := <-s.calls:
+			f()
+		case <-s.quit:
+			s.tu.Dispose()
+			return
+		}
+	}
+}
+
+// exec runs f on the executor goroutine and waits for its completion.
+// A panic in f is propagated to the calling goroutine.
+func (s *SafeTU) exec(f func()) error {
+	var (
+		done = make(chan struct{})
+		perr interface{}
+	)
+	call := func() {
+		defer close(done)
+		defer func() {
+			perr = recover()
+		}()
+		f()
+	}
+
+	select {
+	case s.calls <- call:
+	case <-s.quit:
+		return ErrSafeTUClosed
+	}
+	<-done
+
+	if perr != nil {
+		panic(perr)
+	}
+	return nil
+}
+
+// Do runs f with the wrapped translation unit on the executor goroutine,
+// and waits for its completion.
+// f must not call Do, Reparse or Snapshot on s, nor TUSnapshot.Do or
+// TUSnapshot.Valid on a snapshot of s: the executor goroutine is busy
+// running f, so such calls deadlock.
+func (s *SafeTU) Do(f func(tu TranslationUnit)) error {
+	return s.exec(func() {
+		f(s.tu)
+	})
+}
+
+// Reparse reparses the wrapped translation unit (see
+// TranslationUnit.ReparseErr), invalidating all the snapshots taken so far.
+func (s *SafeTU) Reparse(us UnsavedFiles, options TranslationUnitFlags) error {
+	var err error
+	xerr := s.exec(func() {
+		s.gen++
+		err = s.tu.ReparseErr(us, options)
+	})
+	if xerr != nil {
+		return xerr
+	}
+	return err
+}
+
+// Snapshot returns a read-only view of the translation unit, as of its last
+// reparse.
+// Snapshot copies the whole abstract syntax tree of the translation unit
+// (see TUSnapshot.AST), which may be costly for large translation units.
+func (s *SafeTU) Snapshot() (*TUSnapshot, error) {
+	snap := &TUSnapshot{s: s}
+	err := s.exec(func() {
+		snap.gen = s.gen
+		snap.ast = s.tu.AST(false)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// Close stops the executor goroutine and disposes the wrapped translation
+// unit. Close waits for the call being executed, if any, to complete.
+func (s *SafeTU) Close() error {
+	s.once.Do(func() {
+		close(s.quit)
+	})
+	<-s.done
+	return nil
+}
+
+// TUSnapshot is a read-only view of a SafeTU.
+//
+// The copy of the abstract syntax tree returned by AST can be read by any
+// number of goroutines in parallel, without going through the executor
+// goroutine of the SafeTU. It stays usable after reparses and once the
+// SafeTU is closed.
+// Do gives access to the translation unit itself, until the next reparse:
+// such queries are serialized with all the other calls to the SafeTU.
+type TUSnapshot struct {
+	s   *SafeTU
+	gen uint64
+	ast *ast.TranslationUnit
+}
+
+// AST returns the copy of the abstract syntax tree of the translation unit
+// taken by SafeTU.Snapshot. It must not be modified.
+func (snap *TUSnapshot) AST() *ast.TranslationUnit {
+	return snap.ast
+}
+
+// Do runs f with the translation unit of the snapshot on the executor
+// goroutine of the SafeTU, and waits for its completion.
+// f must not modify the translation unit, and must not use the SafeTU (see
+// SafeTU.Do).
+// Do returns ErrStaleSnapshot if the translation unit was reparsed since
+// the snapshot was taken.
+func (snap *TUSnapshot) Do(f func(tu TranslationUnit)) error {
+	var stale bool
+	err := snap.s.exec(func() {
+		if snap.gen != snap.s.gen {
+			stale = true
+			return
+		}
+		f(snap.s.tu)
+	})
+	if err != nil {
+		return err
+	}
+	if stale {
+		return ErrStaleSnapshot
+	}
+	return nil
+}
+
+// Valid returns whether the snapshot is still valid, i.e. whether the
+// translation unit was not reparsed nor closed since it was taken.
+func (snap *TUSnapshot) Valid() bool {
+	var valid bool
+	err := snap.s.exec(func() {
+		valid = snap.gen == snap.s.gen
+	})
+	return err == nil && valid
+}
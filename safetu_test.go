@@ -0,0 +1,187 @@
+package clang_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/sbinet/go-clang"
+	"github.com/sbinet/go-clang/ast"
+)
+
+func TestSafeTU(t *testing.T) {
+	us := clang.UnsavedFiles{"safe.c": "int a; int b; int c;"}
+
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := idx.Parse("safe.c", nil, us, 0)
+	if !tu.IsValid() {
+		t.Fatal("TranslationUnit is not valid")
+	}
+	stu := clang.NewSafeTU(tu)
+
+	countDecls := func(tu clang.TranslationUnit) int {
+		n := 0
+		tu.ToCursor().Visit(func(cursor, parent clang.Cursor) clang.ChildVisitResult {
+			if cursor.Kind() == clang.CK_VarDecl {
+				n++
+			}
+			return clang.CVR_Continue
+		})
+		return n
+	}
+
+	snap, err := stu.Snapshot()
+	if err != nil {
+		t.Fatalf("error taking snapshot: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var n int
+			err := snap.Do(func(tu clang.TranslationUnit) {
+				n = countDecls(tu)
+			})
+			if err != nil {
+				t.Errorf("error querying snapshot: %v", err)
+			}
+			if n != 3 {
+				t.Errorf("expected 3 declarations. got=%d", n)
+			}
+		}()
+	}
+	wg.Wait()
+
+	us["safe.c"] = "int a; int b;"
+	if err := stu.Reparse(us, 0); err != nil {
+		t.Fatalf("error reparsing: %v", err)
+	}
+	if snap.Valid() {
+		t.Errorf("expected snapshot to be invalidated by reparse")
+	}
+	if err := snap.Do(func(tu clang.TranslationUnit) {}); !errors.Is(err, clang.ErrStaleSnapshot) {
+		t.Errorf("expected ErrStaleSnapshot. got=%v", err)
+	}
+
+	var n int
+	err = stu.Do(func(tu clang.TranslationUnit) {
+		n = countDecls(tu)
+	})
+	if err != nil {
+		t.Fatalf("error querying translation unit: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("expected 2 declarations after reparse. got=%d", n)
+	}
+
+	if err := stu.Close(); err != nil {
+		t.Fatalf("error closing: %v", err)
+	}
+	if err := stu.Do(func(tu clang.TranslationUnit) {}); !errors.Is(err, clang.ErrSafeTUClosed) {
+		t.Errorf("expected ErrSafeTUClosed. got=%v", err)
+	}
+}
+
+func TestSafeTUPanic(t *testing.T) {
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := idx.Parse("safe.c", nil, clang.UnsavedFiles{"safe.c": "int a;"}, 0)
+	if !tu.IsValid() {
+		t.Fatal("TranslationUnit is not valid")
+	}
+	stu := clang.NewSafeTU(tu)
+	defer stu.Close()
+
+	func() {
+		defer func() {
+			if e := recover(); e != "boom" {
+				t.Errorf("expected panic 'boom'. got=%v", e)
+			}
+		}()
+		stu.Do(func(tu clang.TranslationUnit) {
+			panic("boom")
+		})
+	}()
+
+	// executor must still be alive.
+	if err := stu.Do(func(tu clang.TranslationUnit) {}); err != nil {
+		t.Errorf("unexpected error after panic: %v", err)
+	}
+}
+
+func TestSafeTUSnapshotParallel(t *testing.T) {
+	us := clang.UnsavedFiles{"safe.c": "int a; int b; int c;"}
+
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := idx.Parse("safe.c", nil, us, 0)
+	if !tu.IsValid() {
+		t.Fatal("TranslationUnit is not valid")
+	}
+	stu := clang.NewSafeTU(tu)
+	defer stu.Close()
+
+	snap, err := stu.Snapshot()
+	if err != nil {
+		t.Fatalf("error taking snapshot: %v", err)
+	}
+
+	// keep the executor goroutine busy while the snapshot is read.
+	var (
+		busy    = make(chan struct{})
+		release = make(chan struct{})
+		done    = make(chan error)
+	)
+	go func() {
+		done <- stu.Do(func(tu clang.TranslationUnit) {
+			close(busy)
+			<-release
+		})
+	}()
+	<-busy
+
+	// each reader waits for the other one in the middle of its read: the
+	// test only completes if both reads run at the same time.
+	const readers = 2
+	var (
+		inside sync.WaitGroup
+		wg     sync.WaitGroup
+	)
+	inside.Add(readers)
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n := 0
+			ast.Walk(snap.AST().Root, func(node *ast.Node) bool {
+				if node.Kind == "VarDecl" {
+					n++
+				}
+				return true
+			})
+			inside.Done()
+			inside.Wait()
+			if n != 3 {
+				t.Errorf("expected 3 declarations. got=%d", n)
+			}
+		}()
+	}
+	wg.Wait()
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("error running Do: %v", err)
+	}
+
+	// the copy survives a reparse.
+	us["safe.c"] = "int a;"
+	if err := stu.Reparse(us, 0); err != nil {
+		t.Fatalf("error reparsing: %v", err)
+	}
+	if got := len(snap.AST().Root.Children); got != 3 {
+		t.Errorf("expected 3 declarations in the snapshot after reparse. got=%d", got)
+	}
+}
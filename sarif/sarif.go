@@ -0,0 +1,338 @@
+// Package sarif converts clang diagnostics into SARIF 2.1.0 logs.
+//
+// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+// for the specification of the format.
+//
+// ex:
+//
+//	log := sarif.NewLog()
+//	for _, tu := range tus {
+//		diags := tu.Diagnostics()
+//		log.Add(diags)
+//		diags.Dispose()
+//	}
+//	err := log.Encode(os.Stdout)
+package sarif
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/sbinet/go-clang"
+)
+
+const (
+	// Version is the version of the SARIF format produced by this package.
+	Version = "2.1.0"
+
+	// Schema is the URI of the JSON schema of the SARIF format.
+	Schema = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// Log is the top-level object of a SARIF file.
+type Log struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []*Run `json:"runs"`
+}
+
+// Run describes a single invocation of an analysis tool.
+type Run struct {
+	Tool      Tool       `json:"tool"`
+	Artifacts []Artifact `json:"artifacts"`
+	Results   []Result   `json:"results"`
+
+	artifacts map[string]int // artifact URI -> index in Artifacts
+	rules     map[string]int // rule id -> index in Tool.Driver.Rules
+}
+
+// Tool describes the analysis tool which produced a run.
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+// Driver describes the component containing the primary executable of a tool.
+type Driver struct {
+	Name           string `json:"name"`
+	Version        string `json:"version,omitempty"`
+	InformationURI string `json:"informationUri,omitempty"`
+	Rules          []Rule `json:"rules"`
+}
+
+// Rule describes a class of results, identified by a clang warning option.
+type Rule struct {
+	ID string `json:"id"`
+}
+
+// Artifact describes a file referenced by results.
+type Artifact struct {
+	Location ArtifactLocation `json:"location"`
+}
+
+// ArtifactLocation identifies an artifact.
+type ArtifactLocation struct {
+	URI   string `json:"uri"`
+	Index int    `json:"index"`
+}
+
+// Result describes a single diagnostic.
+type Result struct {
+	RuleID           string     `json:"ruleId"`
+	RuleIndex        int        `json:"ruleIndex"`
+	Level            string     `json:"level"`
+	Message          Message    `json:"message"`
+	Locations        []Location `json:"locations,omitempty"`
+	RelatedLocations []Location `json:"relatedLocations,omitempty"`
+	Fixes            []Fix      `json:"fixes,omitempty"`
+}
+
+// Message holds a plain text message.
+type Message struct {
+	Text string `json:"text"`
+}
+
+// Location describes a location in an artifact.
+type Location struct {
+	ID               int              `json:"id,omitempty"`
+	Message          *Message         `json:"message,omitempty"`
+	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+}
+
+// PhysicalLocation describes a region of an artifact.
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Region           Region           `json:"region"`
+}
+
+// Region describes a contiguous part of an artifact.
+// Lines and columns are 1-based. End columns are exclusive.
+type Region struct {
+	StartLine   uint `json:"startLine"`
+	StartColumn uint `json:"startColumn,omitempty"`
+	EndLine     uint `json:"endLine,omitempty"`
+	EndColumn   uint `json:"endColumn,omitempty"`
+}
+
+// Fix describes a proposed fix for a result.
+type Fix struct {
+	Description     *Message         `json:"description,omitempty"`
+	ArtifactChanges []ArtifactChange `json:"artifactChanges"`
+}
+
+// ArtifactChange describes the changes to apply to a single artifact.
+type ArtifactChange struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Replacements     []Replacement    `json:"replacements"`
+}
+
+// Replacement replaces a region of an artifact with new content.
+type Replacement struct {
+	DeletedRegion   Region           `json:"deletedRegion"`
+	InsertedContent *ArtifactContent `json:"insertedContent,omitempty"`
+}
+
+// ArtifactContent holds the content of an artifact or of a part of it.
+type ArtifactContent struct {
+	Text string `json:"text"`
+}
+
+// NewLog creates a SARIF log holding a single run of clang.
+func NewLog() *Log {
+	return &Log{
+		Schema:  Schema,
+		Version: Version,
+		Runs: []*Run{{
+			Tool: Tool{
+				Driver: Driver{
+					Name:           "clang",
+					InformationURI: "https://clang.llvm.org/",
+					Rules:          []Rule{},
+				},
+			},
+			Artifacts: []Artifact{},
+			Results:   []Result{},
+			artifacts: make(map[string]int),
+			rules:     make(map[string]int),
+		}},
+	}
+}
+
+// Add appends the given diagnostics to the last run of the log.
+// Add can be called once per translation unit: files and rules are
+// de-duplicated across calls.
+//
+// Notes attached to a diagnostic are reported as related locations of the
+// corresponding result.
+func (l *Log) Add(diags clang.Diagnostics) {
+	run := l.Runs[len(l.Runs)-1]
+	for _, d := range diags {
+		run.add(d)
+	}
+}
+
+// Encode writes the JSON encoding of the log to w.
+func (l *Log) Encode(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(l)
+}
+
+func (run *Run) add(d clang.Diagnostic) {
+	id := ruleID(d)
+	res := Result{
+		RuleID:    id,
+		RuleIndex: run.rule(id),
+		Level:     level(d.Severity()),
+		Message:   Message{Text: d.Spelling()},
+	}
+
+	if loc, ok := run.location(d.Location(), d.Ranges()); ok {
+		res.Locations = []Location{loc}
+	}
+
+	for i, note := range d.Children() {
+		loc, ok := run.location(note.Location(), note.Ranges())
+		if !ok {
+			continue
+		}
+		loc.ID = i + 1
+		loc.Message = &Message{Text: note.Spelling()}
+		res.RelatedLocations = append(res.RelatedLocations, loc)
+	}
+
+	for _, fix := range d.FixIts() {
+		f, _, _, _ := fix.ReplacementRange.Start().ExpansionLocation()
+		uri := run.artifact(f.Name())
+		if uri.URI == "" {
+			continue
+		}
+		repl := Replacement{DeletedRegion: region(fix.ReplacementRange)}
+		if fix.Data != "" {
+			repl.InsertedContent = &ArtifactContent{Text: fix.Data}
+		}
+		res.Fixes = append(res.Fixes, Fix{
+			ArtifactChanges: []ArtifactChange{{
+				ArtifactLocation: uri,
+				Replacements:     []Replacement{repl},
+			}},
+		})
+	}
+
+	run.Results = append(run.Results, res)
+}
+
+// location returns the SARIF location of a diagnostic.
+// The region covers the first range of the file of loc containing loc, or
+// loc itself.
+func (run *Run) location(loc clang.SourceLocation, ranges []clang.SourceRange) (Location, bool) {
+	f, line, col, _ := loc.ExpansionLocation()
+	uri := run.artifact(f.Name())
+	if uri.URI == "" {
+		return Location{}, false
+	}
+
+	reg := Region{StartLine: line, StartColumn: col}
+	for _, r := range ranges {
+		bf, bline, bcol, _ := r.Start().ExpansionLocation()
+		ef, eline, ecol, _ := r.End().ExpansionLocation()
+		if bf.Name() != f.Name() || ef.Name() != f.Name() {
+			// e.g. a range in an included file.
+			continue
+		}
+		if before(bline, bcol, line, col) && before(line, col, eline, ecol) {
+			reg = region(r)
+			break
+		}
+	}
+
+	return Location{
+		PhysicalLocation: PhysicalLocation{
+			ArtifactLocation: uri,
+			Region:           reg,
+		},
+	}, true
+}
+
+// artifact returns the location of the named file in the artifacts table,
+// adding it if needed.
+func (run *Run) artifact(fname string) ArtifactLocation {
+	if fname == "" {
+		return ArtifactLocation{}
+	}
+	uri := fileURI(fname)
+	idx, ok := run.artifacts[uri]
+	if !ok {
+		idx = len(run.Artifacts)
+		run.artifacts[uri] = idx
+		run.Artifacts = append(run.Artifacts, Artifact{
+			Location: ArtifactLocation{URI: uri, Index: idx},
+		})
+	}
+	return ArtifactLocation{URI: uri, Index: idx}
+}
+
+// rule returns the index of the rule in the rules table, adding it if needed.
+func (run *Run) rule(id string) int {
+	idx, ok := run.rules[id]
+	if !ok {
+		idx = len(run.Tool.Driver.Rules)
+		run.rules[id] = idx
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, Rule{ID: id})
+	}
+	return idx
+}
+
+// ruleID returns the warning option which enabled the diagnostic, or a
+// generic "clang-<severity>" identifier for diagnostics without option
+// (e.g. hard errors).
+func ruleID(d clang.Diagnostic) string {
+	if opt, _ := d.Option(); opt != "" {
+		return opt
+	}
+	return "clang-" + strings.ToLower(d.Severity().String())
+}
+
+func level(sev clang.DiagnosticSeverity) string {
+	switch sev {
+	case clang.Diagnostic_Fatal, clang.Diagnostic_Error:
+		return "error"
+	case clang.Diagnostic_Warning:
+		return "warning"
+	case clang.Diagnostic_Note:
+		return "note"
+	default:
+		return "none"
+	}
+}
+
+func region(r clang.SourceRange) Region {
+	_, bline, bcol, _ := r.Start().ExpansionLocation()
+	_, eline, ecol, _ := r.End().ExpansionLocation()
+	return Region{
+		StartLine:   bline,
+		StartColumn: bcol,
+		EndLine:     eline,
+		EndColumn:   ecol,
+	}
+}
+
+func before(line1, col1, line2, col2 uint) bool {
+	return line1 < line2 || (line1 == line2 && col1 <= col2)
+}
+
+// fileURI converts a file name into a URI.
+// Absolute file names are converted into file:// URIs, relative ones into
+// relative references.
+func fileURI(fname string) string {
+	u := url.URL{Path: filepath.ToSlash(fname)}
+	if filepath.IsAbs(fname) {
+		u.Scheme = "file"
+		if !strings.HasPrefix(u.Path, "/") {
+			u.Path = "/" + u.Path
+		}
+	}
+	return u.String()
+}
@@ -0,0 +1,174 @@
+package sarif_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sbinet/go-clang"
+	"github.com/sbinet/go-clang/sarif"
+)
+
+func parse(t *testing.T, idx clang.Index, fname, src string) clang.TranslationUnit {
+	tu := idx.Parse(fname, []string{"-Wall"}, clang.UnsavedFiles{fname: src}, 0)
+	if !tu.IsValid() {
+		t.Fatalf("TranslationUnit %q is not valid", fname)
+	}
+	return tu
+}
+
+func TestLog(t *testing.T) {
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+
+	tu1 := parse(t, idx, "a.c", `
+int f(int x) {
+	int unused;
+	if (x = 1) {
+		return 1;
+	}
+	return 0
+}
+`)
+	defer tu1.Dispose()
+
+	tu2 := parse(t, idx, "b.c", `
+int g(void) {
+	int unused;
+	return 0;
+}
+`)
+	defer tu2.Dispose()
+
+	log := sarif.NewLog()
+	for _, tu := range []clang.TranslationUnit{tu1, tu2} {
+		diags := tu.Diagnostics()
+		log.Add(diags)
+		diags.Dispose()
+	}
+
+	buf := new(bytes.Buffer)
+	if err := log.Encode(buf); err != nil {
+		t.Fatalf("error encoding log: %v", err)
+	}
+
+	var got sarif.Log
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("error decoding log: %v", err)
+	}
+	if got.Version != sarif.Version {
+		t.Errorf("expected version %q. got=%q", sarif.Version, got.Version)
+	}
+	if len(got.Runs) != 1 {
+		t.Fatalf("expected 1 run. got=%d", len(got.Runs))
+	}
+	run := got.Runs[0]
+
+	if len(run.Artifacts) != 2 {
+		t.Errorf("expected 2 artifacts. got=%d", len(run.Artifacts))
+	}
+
+	rules := make(map[string]int)
+	for i, r := range run.Tool.Driver.Rules {
+		if _, dup := rules[r.ID]; dup {
+			t.Errorf("duplicate rule %q", r.ID)
+		}
+		rules[r.ID] = i
+	}
+	if _, ok := rules["-Wunused-variable"]; !ok {
+		t.Errorf("expected a -Wunused-variable rule. got=%v", run.Tool.Driver.Rules)
+	}
+
+	var (
+		nerrs  int
+		nwarns int
+		nfixes int
+	)
+	for _, res := range run.Results {
+		if rules[res.RuleID] != res.RuleIndex {
+			t.Errorf("invalid rule index for %q: %d", res.RuleID, res.RuleIndex)
+		}
+		switch res.Level {
+		case "error":
+			nerrs++
+		case "warning":
+			nwarns++
+		}
+		if len(res.Locations) != 1 {
+			t.Errorf("expected 1 location for %q. got=%d", res.Message.Text, len(res.Locations))
+			continue
+		}
+		loc := res.Locations[0].PhysicalLocation
+		if loc.Region.StartLine == 0 {
+			t.Errorf("invalid region for %q: %+v", res.Message.Text, loc.Region)
+		}
+		if uri := run.Artifacts[loc.ArtifactLocation.Index].Location.URI; uri != loc.ArtifactLocation.URI {
+			t.Errorf("invalid artifact index for %q: %q != %q", res.Message.Text, uri, loc.ArtifactLocation.URI)
+		}
+		nfixes += len(res.Fixes)
+	}
+	if nerrs != 1 {
+		t.Errorf("expected 1 error. got=%d", nerrs)
+	}
+	if nwarns < 3 {
+		t.Errorf("expected at least 3 warnings. got=%d", nwarns)
+	}
+	if nfixes == 0 {
+		t.Errorf("expected at least one fix")
+	}
+}
+
+func TestLocationOtherFile(t *testing.T) {
+	// the right operand of '+' comes from part.h, and its range spans
+	// lines 1-2 of part.h: by line and column only, it would contain the
+	// location of the '+' in main.c.
+	dir := t.TempDir()
+	for name, src := range map[string]string{
+		"main.c": "double x = 1.0 +\n#include \"part.h\"\n;\n",
+		"part.h": "\"a\"\n\"b\"\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := idx.Parse(filepath.Join(dir, "main.c"), nil, nil, 0)
+	if !tu.IsValid() {
+		t.Fatal("TranslationUnit is not valid")
+	}
+	defer tu.Dispose()
+
+	diags := tu.Diagnostics()
+	defer diags.Dispose()
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic. got=%d", len(diags))
+	}
+	var inPart bool
+	for _, r := range diags[0].Ranges() {
+		if f, _, _, _ := r.Start().ExpansionLocation(); filepath.Base(f.Name()) == "part.h" {
+			inPart = true
+		}
+	}
+	if !inPart {
+		t.Fatalf("expected a diagnostic range in part.h")
+	}
+
+	log := sarif.NewLog()
+	log.Add(diags)
+	res := log.Runs[0].Results[0]
+	if len(res.Locations) != 1 {
+		t.Fatalf("expected 1 location. got=%d", len(res.Locations))
+	}
+	loc := res.Locations[0].PhysicalLocation
+	if got := filepath.Base(loc.ArtifactLocation.URI); got != "main.c" {
+		t.Errorf("expected a location in main.c. got=%q", got)
+	}
+	want := sarif.Region{StartLine: 1, StartColumn: 16}
+	if loc.Region != want {
+		t.Errorf("expected region %+v. got=%+v", want, loc.Region)
+	}
+}
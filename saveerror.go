@@ -0,0 +1,76 @@
+package clang
+
+// #include <stdlib.h>
+// #include "go-clang.h"
+import "C"
+import (
+	"fmt"
+)
+
+/**
+ * \brief Flags that control how translation units are saved.
+ *
+ * The enumerators in this enumeration type are meant to be bitwise
+ * ORed together to specify which options should be used when
+ * saving the translation unit.
+ */
+type SaveOptions uint32
+
+const (
+	/**
+	 * \brief Used to indicate that no special saving options are needed.
+	 */
+	SaveTranslationUnit_None SaveOptions = C.CXSaveTranslationUnit_None
+)
+
+/**
+ * \brief Describes the kind of error that occurred (if any) in a call to
+ * \c clang_saveTranslationUnit().
+ */
+type SaveError int
+
+const (
+	/**
+	 * \brief Indicates that no error occurred while saving a translation unit.
+	 */
+	SaveError_None SaveError = C.CXSaveError_None
+
+	/**
+	 * \brief Indicates that an unknown error occurred while attempting to save
+	 * the file.
+	 *
+	 * This error typically indicates that file I/O failed when attempting to
+	 * write the file.
+	 */
+	SaveError_Unknown SaveError = C.CXSaveError_Unknown
+
+	/**
+	 * \brief Indicates that errors during translation prevented this attempt
+	 * to save the translation unit.
+	 *
+	 * Errors that prevent the translation unit from being saved can be
+	 * extracted using \c clang_getNumDiagnostics() and \c clang_getDiagnostic().
+	 */
+	SaveError_TranslationErrors SaveError = C.CXSaveError_TranslationErrors
+
+	/**
+	 * \brief Indicates that the translation unit to be saved was somehow
+	 * invalid (e.g., NULL).
+	 */
+	SaveError_InvalidTU SaveError = C.CXSaveError_InvalidTU
+)
+
+func (err SaveError) Error() string {
+	switch err {
+	case SaveError_None:
+		return "go-clang: no error"
+	case SaveError_Unknown:
+		return "go-clang: unknown error while saving translation unit"
+	case SaveError_TranslationErrors:
+		return "go-clang: translation errors prevented saving translation unit"
+	case SaveError_InvalidTU:
+		return "go-clang: invalid translation unit"
+	default:
+		return fmt.Sprintf("go-clang: unknown save error (%d)", int(err))
+	}
+}
@@ -0,0 +1,6 @@
+#ifndef INCLUDE_H
+#define INCLUDE_H 1
+
+#include "struct.c"
+
+#endif /* !INCLUDE_H */
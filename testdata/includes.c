@@ -0,0 +1,6 @@
+#include "include.h"
+
+int main(int argc, char **argv) {
+ struct Foo f = {1, 2};
+ return add(f.a, 1);
+}
@@ -0,0 +1,19 @@
+#define ENABLED 1
+
+int always(void) { return 0; }
+
+#if 0
+int never(void) { return 1; }
+#endif
+
+#ifdef UNDEFINED_MACRO
+int undefined(void) { return 2; }
+#else
+int defined(void) { return 3; }
+#endif
+
+#if ENABLED
+int enabled(void) { return 4; }
+#else
+int disabled(void) { return 5; }
+#endif
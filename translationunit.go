@@ -4,6 +4,8 @@ package clang
 // #include "go-clang.h"
 import "C"
 import (
+	"io"
+	"os"
 	"unsafe"
 )
 
@@ -193,6 +195,14 @@ func (tu TranslationUnit) Reparse(us UnsavedFiles, options TranslationUnitFlags)
 	return int(C.clang_reparseTranslationUnit(tu.c, C.uint(len(c_us)), c_us.ptr(), C.uint(options)))
 }
 
+// ReparseErr is the same as Reparse, but returns an ErrorCode describing why
+// the translation unit could not be reparsed.
+// In case of error, the only valid operation on the translation unit is
+// Dispose.
+func (tu TranslationUnit) ReparseErr(us UnsavedFiles, options TranslationUnitFlags) error {
+	return newErrorCode(C.int(tu.Reparse(us, options)))
+}
+
 /**
  * \brief Saves a translation unit into a serialized representation of
  * that translation unit on disk.
@@ -212,16 +222,60 @@ func (tu TranslationUnit) Reparse(us UnsavedFiles, options TranslationUnitFlags)
  * is saved. This should be a bitwise OR of the
  * CXSaveTranslationUnit_XXX flags.
  *
- * \returns A value that will match one of the enumerators of the CXSaveError
- * enumeration. Zero (CXSaveError_None) indicates that the translation unit was
- * saved successfully, while a non-zero value indicates that a problem occurred.
+ * \returns nil if the translation unit was saved successfully, and a
+ * SaveError describing the problem otherwise.
  */
-func (tu TranslationUnit) Save(fname string, options uint) uint32 {
+func (tu TranslationUnit) Save(fname string, options SaveOptions) error {
 	cstr := C.CString(fname)
 	defer C.free(unsafe.Pointer(cstr))
 	o := C.clang_saveTranslationUnit(tu.c, cstr, C.uint(options))
-	// FIXME: should be a SaveError type...
-	return uint32(o)
+	if o != C.CXSaveError_None {
+		return SaveError(o)
+	}
+	return nil
+}
+
+// SaveTo writes the serialized representation of the translation unit to w.
+// As libclang can only save to a file, the translation unit is first saved
+// into a temporary file which is then copied to w.
+func (tu TranslationUnit) SaveTo(w io.Writer, options SaveOptions) error {
+	f, err := os.CreateTemp("", "go-clang-*.ast")
+	if err != nil {
+		return err
+	}
+	fname := f.Name()
+	defer os.Remove(fname)
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	err = tu.Save(fname, options)
+	if err != nil {
+		return err
+	}
+
+	f, err = os.Open(fname)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(w, f)
+	return err
+}
+
+/**
+ * \brief Returns the set of flags that is suitable for saving a translation
+ * unit.
+ *
+ * The set of flags returned provide options for
+ * \c clang_saveTranslationUnit() by default. The returned flag
+ * set contains an unspecified set of options that save translation units with
+ * the most commonly-requested data.
+ */
+func (tu TranslationUnit) DefaultSaveOptions() SaveOptions {
+	return SaveOptions(C.clang_defaultSaveOptions(tu.c))
 }
 
 /**
@@ -245,6 +299,9 @@ func (tu TranslationUnit) Diagnostics() (ret Diagnostics) {
  * \brief Destroy the specified CXTranslationUnit object.
  */
 func (tu TranslationUnit) Dispose() {
+	// wait for the operations abandoned by ReparseContext and
+	// CompleteAtContext.
+	abandonedOps.wait(unsafe.Pointer(tu.c))
 	C.clang_disposeTranslationUnit(tu.c)
 }
 
@@ -282,23 +339,117 @@ func (tu TranslationUnit) TopLevelHeader(m Module, i int) File {
 	return File{C.clang_Module_getTopLevelHeader(tu.c, m.c, C.unsigned(i))}
 }
 
-// TODO
-//
-// /**
-//  * \brief Find #import/#include directives in a specific file.
-//  *
-//  * \param TU translation unit containing the file to query.
-//  *
-//  * \param file to search for #import/#include directives.
-//  *
-//  * \param visitor callback that will receive pairs of CXCursor/CXSourceRange for
-//  * each directive found.
-//  *
-//  * \returns one of the CXResult enumerators.
-//  */
-// CINDEX_LINKAGE CXResult clang_findIncludesInFile(CXTranslationUnit TU,
-//                                                  CXFile file,
-//                                               CXCursorAndRangeVisitor visitor);
+/**
+ * \brief Retrieve all ranges that were skipped by the preprocessor.
+ *
+ * The preprocessor will skip lines when they are surrounded by an
+ * if/ifdef/ifndef directive whose condition does not evaluate to true.
+ *
+ * The skipped ranges are read from the preprocessing record: the translation
+ * unit must be parsed with TU_DetailedPreprocessingRecord.
+ */
+func (tu TranslationUnit) SkippedRanges(f File) []SourceRange {
+	list := C.clang_getSkippedRanges(tu.c, f.c)
+	if list == nil {
+		return nil
+	}
+	defer C.clang_disposeSourceRangeList(list)
+
+	ret := make([]SourceRange, int(list.count))
+	for i := range ret {
+		ret[i] = SourceRange{C._go_clang_sourcerange_at(list.ranges, C.int(i))}
+	}
+	return ret
+}
+
+// Inclusion describes a file included by a translation unit, along with the
+// stack of locations it was included from.
+type Inclusion struct {
+	File File
+
+	// Stack is sorted in order of immediate inclusion: the first element
+	// refers to the location that included File.
+	// Stack is empty for the main file of the translation unit.
+	Stack []SourceLocation
+}
+
+/**
+ * \brief Visitor invoked for each file in a translation unit
+ *        (used with clang_getInclusions()).
+ *
+ * This visitor function will be invoked by clang_getInclusions() for each
+ * file included (either at the top-level or by \#include directives) within
+ * a translation unit.  The first argument is the file being included, and
+ * the second argument provides the inclusion stack.  The
+ * array is sorted in order of immediate inclusion.  For example,
+ * the first element refers to the location that included 'included_file'.
+ */
+type InclusionVisitor func(f File, stack []SourceLocation)
+
+/**
+ * \brief Visit the set of preprocessor inclusions in a translation unit.
+ *   The visitor function is called with the provided data for every included
+ *   file.  This does not include headers included by the PCH file (unless one
+ *   is inspecting the inclusions in the PCH file itself).
+ */
+func (tu TranslationUnit) VisitInclusions(visitor InclusionVisitor) {
+	id := inclusionCallbacks.add(visitor)
+	defer inclusionCallbacks.remove(id)
+
+	C._go_clang_get_inclusions(tu.c, C.uintptr_t(id))
+}
+
+// Inclusions returns the set of files included by the translation unit,
+// each with its full include stack.
+func (tu TranslationUnit) Inclusions() []Inclusion {
+	var incs []Inclusion
+	tu.VisitInclusions(func(f File, stack []SourceLocation) {
+		incs = append(incs, Inclusion{File: f, Stack: stack})
+	})
+	return incs
+}
+
+var inclusionCallbacks callbackRegistry[InclusionVisitor]
+
+//export GoClangInclusionVisitor
+func GoClangInclusionVisitor(file C.CXFile, inclusion_stack *C.CXSourceLocation, include_len C.uint, callback_id unsafe.Pointer) {
+	stack := make([]SourceLocation, int(include_len))
+	for i := range stack {
+		stack[i] = SourceLocation{C._go_clang_sourcelocation_at(inclusion_stack, C.int(i))}
+	}
+	cb := inclusionCallbacks.get(uintptr(callback_id))
+	cb(File{file}, stack)
+}
+
+/**
+ * \brief Find #import/#include directives in a specific file.
+ *
+ * \param TU translation unit containing the file to query.
+ *
+ * \param file to search for #import/#include directives.
+ *
+ * \param visitor callback that will receive pairs of CXCursor/CXSourceRange for
+ * each directive found.
+ *
+ * \returns one of the CXResult enumerators.
+ */
+func (tu TranslationUnit) VisitIncludesInFile(f File, visitor CursorAndRangeVisitor) Result {
+	id := cursorAndRangeCallbacks.add(visitor)
+	defer cursorAndRangeCallbacks.remove(id)
+
+	return Result(C._go_clang_find_includes_in_file(tu.c, f.c, C.uintptr_t(id)))
+}
+
+// FindIncludesInFile returns the #import/#include directives found in a
+// specific file, as pairs of inclusion directive cursors and source ranges.
+func (tu TranslationUnit) FindIncludesInFile(f File) []CursorAndRange {
+	var incs []CursorAndRange
+	tu.VisitIncludesInFile(f, func(cursor Cursor, r SourceRange) VisitorResult {
+		incs = append(incs, CursorAndRange{cursor, r})
+		return VR_Continue
+	})
+	return incs
+}
 
 // TODO
 //
@@ -0,0 +1,163 @@
+package clang_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sbinet/go-clang"
+)
+
+func TestInclusions(t *testing.T) {
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := idx.Parse("testdata/includes.c", nil, nil, 0)
+	if !tu.IsValid() {
+		t.Fatal("TranslationUnit is not valid")
+	}
+	defer tu.Dispose()
+
+	stacks := make(map[string]int)
+	for _, inc := range tu.Inclusions() {
+		stacks[filepath.Base(inc.File.Name())] = len(inc.Stack)
+	}
+	for name, depth := range map[string]int{
+		"includes.c": 0,
+		"include.h":  1,
+		"struct.c":   2,
+	} {
+		n, ok := stacks[name]
+		if !ok {
+			t.Errorf("expected %q to be included", name)
+			continue
+		}
+		if n != depth {
+			t.Errorf("expected an include stack of depth %d for %q. got=%d", depth, name, n)
+		}
+	}
+}
+
+func TestFindIncludesInFile(t *testing.T) {
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := idx.Parse("testdata/includes.c", nil, nil, clang.TU_DetailedPreprocessingRecord)
+	if !tu.IsValid() {
+		t.Fatal("TranslationUnit is not valid")
+	}
+	defer tu.Dispose()
+
+	for _, test := range []struct {
+		fname string
+		want  string
+	}{
+		{"testdata/includes.c", "include.h"},
+		{"testdata/include.h", "struct.c"},
+	} {
+		incs := tu.FindIncludesInFile(tu.File(test.fname))
+		if len(incs) != 1 {
+			t.Errorf("expected 1 include directive in %q. got=%d", test.fname, len(incs))
+			continue
+		}
+		inc := incs[0]
+		if inc.Cursor.Kind() != clang.CK_InclusionDirective {
+			t.Errorf("expected an inclusion directive. got=%v", inc.Cursor.Kind())
+		}
+		if got := filepath.Base(inc.Cursor.IncludedFile().Name()); got != test.want {
+			t.Errorf("expected %q to include %q. got=%q", test.fname, test.want, got)
+		}
+		if inc.Range.IsNull() {
+			t.Errorf("expected a non-null range for the include directive in %q", test.fname)
+		}
+	}
+}
+
+func TestResourceUsage(t *testing.T) {
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := idx.Parse("testdata/struct.c", nil, nil, 0)
+	if !tu.IsValid() {
+		t.Fatal("TranslationUnit is not valid")
+	}
+	defer tu.Dispose()
+
+	usage := tu.ResourceUsage()
+	if len(usage) == 0 {
+		t.Fatal("expected some resource usage entries")
+	}
+	m := usage.Map()
+	for _, e := range usage {
+		name := e.Kind.String()
+		if name == "" {
+			t.Errorf("expected a name for resource usage kind %d", int(e.Kind))
+		}
+		if _, ok := m[name]; !ok {
+			t.Errorf("expected %q in resource usage map", name)
+		}
+	}
+	if usage.MemoryInBytes() == 0 {
+		t.Errorf("expected a non-zero memory usage")
+	}
+}
+
+func TestSkippedRanges(t *testing.T) {
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := idx.Parse("testdata/skipped.c", nil, nil, clang.TU_DetailedPreprocessingRecord)
+	if !tu.IsValid() {
+		t.Fatal("TranslationUnit is not valid")
+	}
+	defer tu.Dispose()
+
+	f := tu.File("testdata/skipped.c")
+	ranges := tu.SkippedRanges(f)
+	if len(ranges) != 3 {
+		t.Fatalf("expected 3 skipped ranges. got=%d", len(ranges))
+	}
+
+	// each skipped range must cover the disabled function definition.
+	for i, line := range []uint{6, 10, 18} {
+		_, bline, _, _ := ranges[i].Start().SpellingLocation()
+		_, eline, _, _ := ranges[i].End().SpellingLocation()
+		if !(bline < line && line < eline) {
+			t.Errorf("range #%d: expected line %d to be skipped. got=[%d, %d]", i, line, bline, eline)
+		}
+	}
+}
+
+func TestSaveTo(t *testing.T) {
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := idx.Parse("testdata/struct.c", nil, nil, 0)
+	if !tu.IsValid() {
+		t.Fatal("TranslationUnit is not valid")
+	}
+	defer tu.Dispose()
+
+	buf := new(bytes.Buffer)
+	if err := tu.SaveTo(buf, tu.DefaultSaveOptions()); err != nil {
+		t.Fatalf("error saving translation unit: %v", err)
+	}
+	if buf.Len() == 0 {
+		t.Fatal("expected a non-empty serialized translation unit")
+	}
+
+	fname := filepath.Join(t.TempDir(), "struct.ast")
+	if err := os.WriteFile(fname, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ast, err := idx.LoadErr(fname)
+	if err != nil {
+		t.Fatalf("error loading saved translation unit: %v", err)
+	}
+	defer ast.Dispose()
+	if got, want := ast.ToCursor().Spelling(), tu.ToCursor().Spelling(); got != want {
+		t.Errorf("expected translation unit %q. got=%q", want, got)
+	}
+
+	err = clang.TranslationUnit{}.SaveTo(new(bytes.Buffer), clang.SaveTranslationUnit_None)
+	if !errors.Is(err, clang.SaveError_InvalidTU) {
+		t.Errorf("expected SaveError_InvalidTU. got=%v", err)
+	}
+}
@@ -94,6 +94,15 @@ func (t Type) Declaration() Cursor {
 	return Cursor{o}
 }
 
+/**
+ * \brief Retrieve the calling convention associated with a function type.
+ *
+ * If a non-function type is passed in, CXCallingConv_Invalid is returned.
+ */
+func (t Type) CallingConv() CallingConv {
+	return CallingConv(C.clang_getFunctionTypeCallingConv(t.c))
+}
+
 /**
  * \brief Retrieve the result type associated with a function type.
  */
@@ -102,6 +111,38 @@ func (t Type) ResultType() Type {
 	return Type{o}
 }
 
+/**
+ * \brief Retrieve the number of non-variadic parameters associated with a
+ * function type.
+ *
+ * If a non-function type is passed in, -1 is returned.
+ */
+func (t Type) NumArgTypes() int {
+	return int(C.clang_getNumArgTypes(t.c))
+}
+
+/**
+ * \brief Retrieve the type of a parameter of a function type.
+ *
+ * If a non-function type is passed in or the function does not have enough
+ * parameters, an invalid type is returned.
+ */
+func (t Type) ArgType(i uint) Type {
+	o := C.clang_getArgType(t.c, C.uint(i))
+	return Type{o}
+}
+
+/**
+ * \brief Return 1 if the CXType is a variadic function type, and 0 otherwise.
+ */
+func (t Type) IsFunctionVariadic() bool {
+	o := C.clang_isFunctionTypeVariadic(t.c)
+	if o != C.uint(0) {
+		return true
+	}
+	return false
+}
+
 /**
  * \brief Return 1 if the CXType is a POD (plain old data) type, and 0
  *  otherwise.
@@ -134,6 +175,28 @@ func (t Type) ArraySize() int64 {
 	return int64(o)
 }
 
+/**
+ * \brief Return the element type of an array, complex, or vector type.
+ *
+ * If a type is passed in that is not an array, complex, or vector type,
+ * an invalid type is returned.
+ */
+func (t Type) ElementType() Type {
+	o := C.clang_getElementType(t.c)
+	return Type{o}
+}
+
+/**
+ * \brief Return the number of elements of an array or vector type.
+ *
+ * If a type is passed in that is not an array or vector type,
+ * -1 is returned.
+ */
+func (t Type) NumElements() int64 {
+	o := C.clang_getNumElements(t.c)
+	return int64(o)
+}
+
 /**
  * \brief Return the alignment of a type in bytes as per C++[expr.alignof]
  *   standard.
@@ -213,4 +276,43 @@ func (t Type) CXXRefQualifier() RefQualifierKind {
 	return RefQualifierKind(C.clang_Type_getCXXRefQualifier(t.c))
 }
 
+/**
+ * \brief Returns the number of template arguments for given class template
+ * specialization, or -1 if type \c T is not a class template specialization.
+ *
+ * Variadic argument packs count as only one argument, and can not be inspected
+ * further.
+ */
+func (t Type) NumTemplateArguments() int {
+	return int(C.clang_Type_getNumTemplateArguments(t.c))
+}
+
+/**
+ * \brief Returns the type template argument of a template class specialization
+ * at given index.
+ *
+ * This function only returns template type arguments and does not handle
+ * template template arguments or variadic packs.
+ */
+func (t Type) TemplateArgumentAsType(i uint) Type {
+	return Type{C.clang_Type_getTemplateArgumentAsType(t.c, C.uint(i))}
+}
+
+// TemplateArguments returns the template arguments of a class template
+// specialization, or nil if t is not a class template specialization.
+// Arguments which are not types (e.g. integral values or template template
+// arguments) are returned as invalid types, so the position of each
+// argument is preserved.
+func (t Type) TemplateArguments() []Type {
+	n := t.NumTemplateArguments()
+	if n < 0 {
+		return nil
+	}
+	ret := make([]Type, n)
+	for i := range ret {
+		ret[i] = t.TemplateArgumentAsType(uint(i))
+	}
+	return ret
+}
+
 // EOF
@@ -0,0 +1,145 @@
+package clang_test
+
+import (
+	"testing"
+
+	"github.com/sbinet/go-clang"
+)
+
+func TestFunctionType(t *testing.T) {
+	us := clang.UnsavedFiles{"types.c": `
+typedef int (*callback_t)(double x, const char *fmt, ...);
+typedef float vec4 __attribute__((ext_vector_type(4)));
+typedef _Complex double cplx;
+`}
+
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := idx.Parse("types.c", nil, us, 0)
+	if !tu.IsValid() {
+		t.Fatal("TranslationUnit is not valid")
+	}
+	defer tu.Dispose()
+
+	typedefs := make(map[string]clang.Type)
+	tu.ToCursor().Visit(func(cursor, parent clang.Cursor) clang.ChildVisitResult {
+		if cursor.Kind() == clang.CK_TypedefDecl {
+			typedefs[cursor.Spelling()] = cursor.TypedefDeclUnderlyingType()
+		}
+		return clang.CVR_Continue
+	})
+
+	fct := typedefs["callback_t"].PointeeType()
+	if fct.Kind() != clang.TK_FunctionProto {
+		t.Fatalf("expected a function prototype. got=%v", fct.Kind())
+	}
+	if n := fct.NumArgTypes(); n != 2 {
+		t.Fatalf("expected 2 arguments. got=%d", n)
+	}
+	for i, want := range []string{"double", "const char *"} {
+		if got := fct.ArgType(uint(i)).TypeSpelling(); got != want {
+			t.Errorf("argument #%d: expected %q. got=%q", i, want, got)
+		}
+	}
+	if !fct.IsFunctionVariadic() {
+		t.Errorf("expected a variadic function type")
+	}
+	if cc := fct.CallingConv(); cc == clang.CallingConv_Invalid {
+		t.Errorf("expected a valid calling convention")
+	}
+	if got := fct.ResultType().TypeSpelling(); got != "int" {
+		t.Errorf("expected result type 'int'. got=%q", got)
+	}
+
+	vec := typedefs["vec4"].CanonicalType()
+	if n := vec.NumElements(); n != 4 {
+		t.Errorf("expected 4 vector elements. got=%d", n)
+	}
+	if got := vec.ElementType().TypeSpelling(); got != "float" {
+		t.Errorf("expected vector element type 'float'. got=%q", got)
+	}
+
+	cplx := typedefs["cplx"].CanonicalType()
+	if cplx.Kind() != clang.TK_Complex {
+		t.Fatalf("expected a complex type. got=%v", cplx.Kind())
+	}
+	if got := cplx.ElementType().TypeSpelling(); got != "double" {
+		t.Errorf("expected complex element type 'double'. got=%q", got)
+	}
+
+	if n := cplx.NumArgTypes(); n != -1 {
+		t.Errorf("expected -1 arguments for a non-function type. got=%d", n)
+	}
+}
+
+func TestTemplateArguments(t *testing.T) {
+	us := clang.UnsavedFiles{"templates.cxx": `
+template <typename T, int N> struct Array { T data[N]; };
+template <typename K, typename V> struct Pair { K k; V v; };
+template <typename V> struct Pair<int, V> { V v; };
+
+Array<unsigned, 3> a;
+Pair<int, double> p;
+int i;
+`}
+
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := idx.Parse("templates.cxx", []string{"-x", "c++"}, us, 0)
+	if !tu.IsValid() {
+		t.Fatal("TranslationUnit is not valid")
+	}
+	defer tu.Dispose()
+
+	vars := make(map[string]clang.Type)
+	tu.ToCursor().Visit(func(cursor, parent clang.Cursor) clang.ChildVisitResult {
+		if cursor.Kind() == clang.CK_VarDecl {
+			vars[cursor.Spelling()] = cursor.Type()
+		}
+		return clang.CVR_Continue
+	})
+
+	for _, test := range []struct {
+		name string
+		tmpl string
+		args []string // empty for non-type arguments
+	}{
+		{"a", "Array", []string{"unsigned int", ""}},
+		{"p", "Pair", []string{"int", "double"}},
+	} {
+		typ := vars[test.name]
+		args := typ.TemplateArguments()
+		if len(args) != len(test.args) {
+			t.Errorf("%s: expected %d template arguments. got=%d", test.name, len(test.args), len(args))
+			continue
+		}
+		for i, arg := range args {
+			got := ""
+			if arg.Kind() != clang.TK_Invalid {
+				got = arg.TypeSpelling()
+			}
+			if got != test.args[i] {
+				t.Errorf("%s: argument #%d: expected %q. got=%q", test.name, i, test.args[i], got)
+			}
+		}
+
+		primary, args, ok := typ.Declaration().TemplateSpecialization()
+		if !ok {
+			t.Errorf("%s: expected a template specialization", test.name)
+			continue
+		}
+		if primary.Kind() != clang.CK_ClassTemplate || primary.Spelling() != test.tmpl {
+			t.Errorf("%s: expected primary template %q. got=%q (%v)", test.name, test.tmpl, primary.Spelling(), primary.Kind())
+		}
+		if len(args) != len(test.args) {
+			t.Errorf("%s: expected %d specialization arguments. got=%d", test.name, len(test.args), len(args))
+		}
+	}
+
+	if args := vars["i"].TemplateArguments(); args != nil {
+		t.Errorf("expected no template arguments for 'int'. got=%v", args)
+	}
+	if _, _, ok := vars["i"].Declaration().TemplateSpecialization(); ok {
+		t.Errorf("expected 'int' not to be a template specialization")
+	}
+}
@@ -13,6 +13,18 @@ type (
 	cUnsavedFiles []C.struct_CXUnsavedFile
 )
 
+// clone returns a copy of us.
+func (us UnsavedFiles) clone() UnsavedFiles {
+	if us == nil {
+		return nil
+	}
+	ret := make(UnsavedFiles, len(us))
+	for k, v := range us {
+		ret[k] = v
+	}
+	return ret
+}
+
 func (us UnsavedFiles) to_c() (ret cUnsavedFiles) {
 	ret = make(cUnsavedFiles, 0, len(us))
 	for filename, contents := range us {
@@ -0,0 +1,53 @@
+package clang
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// CursorVisitorErr is a CursorVisitor which can report an error.
+// Returning a non-nil error ends the traversal.
+type CursorVisitorErr func(cursor, parent Cursor) (ChildVisitResult, error)
+
+// VisitorPanicError is returned by Cursor.VisitErr when the visitor panicked.
+type VisitorPanicError struct {
+	Value interface{} // value passed to panic
+	Stack []byte      // stack trace of the panicking goroutine
+}
+
+func (err *VisitorPanicError) Error() string {
+	return fmt.Sprintf("go-clang: panic in visitor: %v", err.Value)
+}
+
+// Unwrap returns the value passed to panic, if it is an error.
+func (err *VisitorPanicError) Unwrap() error {
+	if e, ok := err.Value.(error); ok {
+		return e
+	}
+	return nil
+}
+
+// VisitErr is the same as Visit, but stops the traversal on the first error
+// returned by visitor, and returns it.
+//
+// A panic raised by visitor is recovered before it can unwind through the
+// C frames of libclang: the traversal is then ended and a
+// *VisitorPanicError is returned.
+func (c Cursor) VisitErr(visitor CursorVisitorErr) error {
+	var err error
+	c.Visit(func(cursor, parent Cursor) (status ChildVisitResult) {
+		defer func() {
+			if e := recover(); e != nil {
+				err = &VisitorPanicError{Value: e, Stack: debug.Stack()}
+				status = CVR_Break
+			}
+		}()
+
+		status, err = visitor(cursor, parent)
+		if err != nil {
+			return CVR_Break
+		}
+		return status
+	})
+	return err
+}
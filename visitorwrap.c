@@ -1,6 +1,8 @@
 /* helper functions to visit cursors
  */
 
+#include <stdlib.h>
+
 #include "_cgo_export.h"
 #include "go-clang.h"
 
@@ -10,4 +12,87 @@ _go_clang_visit_children(CXCursor c, uintptr_t callback_id)
   return clang_visitChildren(c, (CXCursorVisitor)&GoClangCursorVisitor, (CXClientData)callback_id);
 }
 
+void
+_go_clang_get_inclusions(CXTranslationUnit tu, uintptr_t callback_id)
+{
+  clang_getInclusions(tu, (CXInclusionVisitor)&GoClangInclusionVisitor, (CXClientData)callback_id);
+}
+
+CXResult
+_go_clang_find_includes_in_file(CXTranslationUnit tu, CXFile file, uintptr_t callback_id)
+{
+  CXCursorAndRangeVisitor visitor = {
+    callback_id,
+    (enum CXVisitorResult (*)(void *, CXCursor, CXSourceRange))&GoClangCursorAndRangeVisitor,
+  };
+  return clang_findIncludesInFile(tu, file, visitor);
+}
+
+CXResult
+_go_clang_find_references_in_file(CXCursor c, CXFile file, uintptr_t callback_id)
+{
+  CXCursorAndRangeVisitor visitor = {
+    callback_id,
+    (enum CXVisitorResult (*)(void *, CXCursor, CXSourceRange))&GoClangCursorAndRangeVisitor,
+  };
+  return clang_findReferencesInFile(c, file, visitor);
+}
+
+typedef struct {
+  CXCursor *cursors;
+  unsigned len;
+  unsigned cap;
+  const unsigned char *kinds; /* kinds[k] != 0 if kind k is collected */
+  unsigned nkinds;
+  enum CXChildVisitResult next;
+  int oom;
+} _go_clang_cursor_buffer;
+
+static enum CXChildVisitResult
+_go_clang_collect_visitor(CXCursor c, CXCursor parent, CXClientData data)
+{
+  _go_clang_cursor_buffer *buf = (_go_clang_cursor_buffer*)data;
+  unsigned kind = (unsigned)clang_getCursorKind(c);
+
+  if (buf->kinds != NULL && (kind >= buf->nkinds || !buf->kinds[kind])) {
+    return buf->next;
+  }
+
+  if (buf->len == buf->cap) {
+    unsigned cap = buf->cap == 0 ? 64 : 2 * buf->cap;
+    CXCursor *cursors = (CXCursor*)realloc(buf->cursors, cap * sizeof(CXCursor));
+    if (cursors == NULL) {
+      buf->oom = 1;
+      return CXChildVisit_Break;
+    }
+    buf->cursors = cursors;
+    buf->cap = cap;
+  }
+  buf->cursors[buf->len++] = c;
+  return buf->next;
+}
+
+int
+_go_clang_collect_children(CXCursor c, int recurse,
+                           const unsigned char *kinds, unsigned nkinds,
+                           CXCursor **out_cursors, unsigned *out_len)
+{
+  _go_clang_cursor_buffer buf = {
+    NULL, 0, 0,
+    kinds, nkinds,
+    recurse ? CXChildVisit_Recurse : CXChildVisit_Continue,
+    0,
+  };
+  clang_visitChildren(c, &_go_clang_collect_visitor, (CXClientData)&buf);
+  if (buf.oom) {
+    free(buf.cursors);
+    *out_cursors = NULL;
+    *out_len = 0;
+    return -1;
+  }
+  *out_cursors = buf.cursors;
+  *out_len = buf.len;
+  return 0;
+}
+
 /* EOF */
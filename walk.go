@@ -0,0 +1,65 @@
+package clang
+
+// Children returns the direct children of c.
+func (c Cursor) Children() []Cursor {
+	return c.CollectChildren()
+}
+
+// WalkFunc is invoked by Walk for each cursor of the walked tree.
+//
+// depth is the depth of c in the tree: 0 for the root of the walk.
+// parents holds the ancestors of c, from the root of the walk (parents[0])
+// to the direct parent of c (parents[depth-1]). The parents slice is reused
+// by Walk and must not be retained.
+type WalkFunc func(c Cursor, depth int, parents []Cursor) ChildVisitResult
+
+// Walk traverses the tree of cursors rooted at root, depth-first.
+//
+// pre is invoked for each cursor before its children are walked, and
+// directs the traversal:
+//   - CVR_Recurse walks the children of the cursor,
+//   - CVR_Continue skips the children of the cursor,
+//   - CVR_Break ends the traversal.
+//
+// post is invoked for each cursor after its children were walked (or
+// skipped). If post returns CVR_Break, the traversal is ended.
+//
+// Either pre or post may be nil. A nil pre walks the whole tree.
+//
+// Walk returns false if the traversal was ended prematurely.
+func Walk(root Cursor, pre, post WalkFunc) bool {
+	w := walker{pre: pre, post: post}
+	return w.walk(root)
+}
+
+type walker struct {
+	pre     WalkFunc
+	post    WalkFunc
+	parents []Cursor
+}
+
+func (w *walker) walk(c Cursor) bool {
+	depth := len(w.parents)
+
+	res := ChildVisitResult(CVR_Recurse)
+	if w.pre != nil {
+		res = w.pre(c, depth, w.parents)
+	}
+	switch res {
+	case CVR_Break:
+		return false
+	case CVR_Recurse:
+		w.parents = append(w.parents, c)
+		for _, child := range c.Children() {
+			if !w.walk(child) {
+				return false
+			}
+		}
+		w.parents = w.parents[:depth]
+	}
+
+	if w.post != nil && w.post(c, depth, w.parents) == CVR_Break {
+		return false
+	}
+	return true
+}
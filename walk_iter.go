@@ -0,0 +1,42 @@
+//go:build go1.23
+
+package clang
+
+import (
+	"iter"
+)
+
+// All returns an iterator over the direct children of c.
+func (c Cursor) All() iter.Seq[Cursor] {
+	return func(yield func(Cursor) bool) {
+		for _, child := range c.Children() {
+			if !yield(child) {
+				return
+			}
+		}
+	}
+}
+
+// Descendants returns an iterator over all the descendants of c, in
+// depth-first pre-order. c itself is not part of the sequence.
+func (c Cursor) Descendants() iter.Seq[Cursor] {
+	return func(yield func(Cursor) bool) {
+		// stack of the remaining siblings to visit, for each level.
+		stack := [][]Cursor{c.Children()}
+		for len(stack) > 0 {
+			top := len(stack) - 1
+			if len(stack[top]) == 0 {
+				stack = stack[:top]
+				continue
+			}
+			cur := stack[top][0]
+			stack[top] = stack[top][1:]
+			if !yield(cur) {
+				return
+			}
+			if children := cur.Children(); len(children) > 0 {
+				stack = append(stack, children)
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+//go:build go1.23
+
+package clang_test
+
+import (
+	"testing"
+
+	"github.com/sbinet/go-clang"
+)
+
+func TestCursorIterators(t *testing.T) {
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := parseWalkSrc(t, idx)
+	defer tu.Dispose()
+
+	root := tu.ToCursor()
+
+	var names []string
+	for c := range root.All() {
+		names = append(names, c.Spelling())
+	}
+	if len(names) != 3 || names[0] != "Foo" || names[1] != "Bar" || names[2] != "add" {
+		t.Errorf("expected [Foo Bar add]. got=%v", names)
+	}
+
+	names = names[:0]
+	for c := range root.Descendants() {
+		if c.Kind() == clang.CK_FieldDecl {
+			names = append(names, c.Spelling())
+		}
+	}
+	if len(names) != 4 || names[0] != "a" || names[1] != "b" || names[2] != "foo" || names[3] != "c" {
+		t.Errorf("expected fields [a b foo c]. got=%v", names)
+	}
+
+	// early exit.
+	var last clang.Cursor
+	for c := range root.Descendants() {
+		last = c
+		if c.Kind() == clang.CK_FieldDecl {
+			break
+		}
+	}
+	if last.Spelling() != "a" {
+		t.Errorf("expected iteration to stop at 'a'. got=%q", last.Spelling())
+	}
+}
@@ -0,0 +1,96 @@
+package clang_test
+
+import (
+	"testing"
+
+	"github.com/sbinet/go-clang"
+)
+
+const walkSrc = `
+struct Foo { int a; float b; };
+struct Bar { struct Foo foo; int c; };
+int add(int a, int b) { return a + b; }
+`
+
+func parseWalkSrc(t testing.TB, idx clang.Index) clang.TranslationUnit {
+	tu := idx.Parse("walk.c", nil, clang.UnsavedFiles{"walk.c": walkSrc}, 0)
+	if !tu.IsValid() {
+		t.Fatal("TranslationUnit is not valid")
+	}
+	return tu
+}
+
+func TestChildren(t *testing.T) {
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := parseWalkSrc(t, idx)
+	defer tu.Dispose()
+
+	var names []string
+	for _, c := range tu.ToCursor().Children() {
+		names = append(names, c.Spelling())
+	}
+	if len(names) != 3 || names[0] != "Foo" || names[1] != "Bar" || names[2] != "add" {
+		t.Errorf("expected [Foo Bar add]. got=%v", names)
+	}
+}
+
+func TestWalk(t *testing.T) {
+	idx := clang.NewIndex(0, 0)
+	defer idx.Dispose()
+	tu := parseWalkSrc(t, idx)
+	defer tu.Dispose()
+
+	// count the fields of each struct in post-order.
+	var (
+		fields = make(map[string]int)
+		order  []string
+	)
+	pre := func(c clang.Cursor, depth int, parents []clang.Cursor) clang.ChildVisitResult {
+		if len(parents) != depth {
+			t.Errorf("%s: expected %d parents. got=%d", c.Spelling(), depth, len(parents))
+		}
+		if c.Kind() == clang.CK_FunctionDecl {
+			return clang.CVR_Continue
+		}
+		return clang.CVR_Recurse
+	}
+	post := func(c clang.Cursor, depth int, parents []clang.Cursor) clang.ChildVisitResult {
+		switch c.Kind() {
+		case clang.CK_FieldDecl:
+			if depth != 2 || parents[1].Kind() != clang.CK_StructDecl {
+				t.Errorf("%s: unexpected depth=%d", c.Spelling(), depth)
+			}
+			fields[parents[1].Spelling()]++
+		case clang.CK_StructDecl, clang.CK_FunctionDecl:
+			order = append(order, c.Spelling())
+		}
+		return clang.CVR_Continue
+	}
+	if !clang.Walk(tu.ToCursor(), pre, post) {
+		t.Fatal("expected a complete walk")
+	}
+	if fields["Foo"] != 2 || fields["Bar"] != 2 {
+		t.Errorf("expected 2 fields for Foo and Bar. got=%v", fields)
+	}
+	if len(order) != 3 || order[0] != "Foo" || order[1] != "Bar" || order[2] != "add" {
+		t.Errorf("expected post-order [Foo Bar add]. got=%v", order)
+	}
+
+	// early exit.
+	n := 0
+	done := clang.Walk(tu.ToCursor(), func(c clang.Cursor, depth int, parents []clang.Cursor) clang.ChildVisitResult {
+		n++
+		if c.Spelling() == "b" {
+			return clang.CVR_Break
+		}
+		return clang.CVR_Recurse
+	}, nil)
+	if done {
+		t.Errorf("expected walk to be ended prematurely")
+	}
+	// root, Foo, a, b
+	if n != 4 {
+		t.Errorf("expected 4 visited cursors. got=%d", n)
+	}
+}